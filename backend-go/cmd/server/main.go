@@ -1,12 +1,22 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/paulhuang/paulfun-blogger/internal/config"
 	"github.com/paulhuang/paulfun-blogger/internal/db"
+	"github.com/paulhuang/paulfun-blogger/internal/fingerprint"
 	"github.com/paulhuang/paulfun-blogger/internal/handlers"
 	"github.com/paulhuang/paulfun-blogger/internal/router"
+	"github.com/paulhuang/paulfun-blogger/internal/scheduler"
 	"github.com/paulhuang/paulfun-blogger/internal/services"
 	"github.com/paulhuang/paulfun-blogger/internal/storage"
 )
@@ -41,11 +51,56 @@ func main() {
 	// 5. 初始化 Services
 	authSvc := services.NewAuthService(database, cfg)
 	articleSvc := services.NewArticleService(database)
+	reactionTypes, err := services.ParseReactionTypes(cfg.ReactionTypes)
+	if err != nil {
+		log.Fatalf("REACTION_TYPES 設定錯誤: %v", err)
+	}
+	articleSvc.SetReactionTypes(reactionTypes)
 	mediaSvc := services.NewMediaService(database, store)
 	importSvc := services.NewImportService(database)
 	categorySvc := services.NewCategoryService(database)
+	tagSvc := services.NewTagService(database)
+	feedSvc := services.NewFeedService(database, cfg.BaseURL)
+	sitemapSvc := services.NewSitemapService(database, cfg.BaseURL)
+	articleSvc.OnPublicChange(sitemapSvc.Invalidate)
+	articleSvc.OnPublicChange(feedSvc.MarkChanged)
+	commentSvc := services.NewCommentService(database)
+	spamSvc := services.NewSpamService(database, services.SpamSettings{
+		Enabled:          cfg.SpamFilterEnabled,
+		Strategies:       cfg.SpamStrategies,
+		MinSubmitSeconds: cfg.SpamMinSubmitSeconds,
+		MaxLinks:         cfg.SpamMaxLinks,
+		Blocklist:        cfg.SpamBlocklist,
+		DuplicateWindow:  time.Duration(cfg.SpamDuplicateWindowMinutes) * time.Minute,
+		LogAllowed:       cfg.SpamLogAllowed,
+		TokenSecret:      cfg.SpamTokenSecret,
+	})
+	readers := fingerprint.New(cfg.ReaderSecret, time.Duration(cfg.ReaderSaltHours)*time.Hour)
+	viewTracker := services.NewViewTracker(database, services.ViewTrackerSettings{
+		DedupeWindow:  time.Duration(cfg.ViewDedupeMinutes) * time.Minute,
+		FlushInterval: time.Duration(cfg.ViewFlushSeconds) * time.Second,
+		BufferSize:    cfg.ViewBufferSize,
+	})
+	viewTracker.Start()
+	analyticsSvc := services.NewAnalyticsService(database, cfg.BaseURL, services.AnalyticsSettings{
+		Enabled:       cfg.AnalyticsEnabled,
+		FlushInterval: time.Duration(cfg.AnalyticsFlushSeconds) * time.Second,
+	})
+	analyticsSvc.Start()
+	rankingSvc := services.NewRankingService(database, services.RankingSettings{
+		RefreshInterval: time.Duration(cfg.RankingRefreshMinutes) * time.Minute,
+		HalfLife:        time.Duration(cfg.TrendingHalfLifeHours) * time.Hour,
+		LikeWeight:      cfg.TrendingLikeWeight,
+	})
 	satSvc := services.NewSATService(database)
 	linkSvc := services.NewArticleLinkService(database)
+	articleSvc.OnPublicChange(linkSvc.InvalidateSuggestions)
+	seriesSvc := services.NewSeriesService(database)
+	retentionSvc := services.NewArchiveRetentionService(database, services.ArchiveRetentionPolicy{
+		KeepLast:          cfg.ArchiveKeepLast,
+		DailyAfterDays:    cfg.ArchiveDailyAfterDays,
+		CompressAfterDays: cfg.ArchiveCompressAfterDays,
+	})
 
 	// 5a. 確保「未分類」固定分類存在
 	// DELETE 任何分類時，文章會被 reassign 到此處；本身不可刪。
@@ -53,24 +108,108 @@ func main() {
 		log.Fatalf("EnsureUncategorized 失敗: %v", err)
 	}
 
+	// 5b. 背景排程：到期的 scheduled 文章轉為 published
+	runner := scheduler.New()
+	hostname, _ := os.Hostname()
+	publishActor := "scheduler@" + hostname
+	runner.Register("publish-scheduled", time.Duration(cfg.PublishIntervalSeconds)*time.Second,
+		func(ctx context.Context) (string, error) {
+			ids, err := articleSvc.PromoteDueScheduled(publishActor)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("promoted %d article(s)", len(ids)), nil
+		})
+	// 5c. 背景排程：套用 article_archives 保留策略
+	if cfg.ArchiveRetentionEnabled {
+		runner.Register("archive-retention", time.Duration(cfg.ArchiveRetentionHours)*time.Hour,
+			func(ctx context.Context) (string, error) {
+				report, err := retentionSvc.Apply(retentionSvc.Policy(), nil, false)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("deleted %d, compressed %d archive(s)", report.Deleted, report.Compressed), nil
+			})
+	}
+	// 5d. 背景排程：永久刪除垃圾桶中超過保留期的文章
+	runner.Register("purge-trash", time.Hour,
+		func(ctx context.Context) (string, error) {
+			ids, err := articleSvc.PurgeExpiredTrash(cfg.TrashRetentionDays)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("purged %d article(s)", len(ids)), nil
+		})
+	// 5e. 背景排程：以 article_likes 校正 like_count
+	runner.Register("reconcile-likes", time.Duration(cfg.LikeReconcileMinutes)*time.Minute,
+		func(ctx context.Context) (string, error) {
+			n, err := articleSvc.ReconcileLikeCounts()
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("reconciled %d article(s)", n), nil
+		})
+	// 5f. 背景排程：重算熱門 / 趨勢文章排行（停用時改由請求時就地重算）
+	runner.Register("refresh-rankings", rankingSvc.JobInterval(),
+		func(ctx context.Context) (string, error) {
+			n, err := rankingSvc.Refresh()
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("refreshed %d ranking(s)", n), nil
+		})
+	if cfg.SchedulerEnabled {
+		runner.Start(context.Background())
+	} else {
+		log.Println("Scheduler: 已停用（SCHEDULER_ENABLED=false）")
+	}
+
 	// 6. 初始化 Handlers
 	h := router.Handlers{
 		Auth:        handlers.NewAuthHandler(authSvc, satSvc),
-		Article:     handlers.NewArticleHandler(articleSvc),
+		Article:     handlers.NewArticleHandler(articleSvc, spamSvc, readers, viewTracker),
 		Admin:       handlers.NewAdminHandler(articleSvc),
 		Media:       handlers.NewMediaHandler(mediaSvc),
 		Import:      handlers.NewImportHandler(importSvc),
 		Category:    handlers.NewCategoryHandler(categorySvc),
 		SATAdmin:    handlers.NewSATAdminHandler(satSvc),
 		ArticleLink: handlers.NewArticleLinkHandler(linkSvc),
+		Scheduler:   handlers.NewSchedulerHandler(runner, articleSvc),
+		Archive:     handlers.NewArchiveHandler(retentionSvc),
+		Trash:       handlers.NewTrashHandler(articleSvc, cfg.TrashRetentionDays),
+		Tag:         handlers.NewTagHandler(tagSvc),
+		Feed:        handlers.NewFeedHandler(feedSvc),
+		Sitemap:     handlers.NewSitemapHandler(sitemapSvc),
+		Comment:     handlers.NewCommentHandler(commentSvc, spamSvc),
+		Spam:        handlers.NewSpamHandler(spamSvc),
+		Analytics:   handlers.NewAnalyticsHandler(analyticsSvc, readers),
+		Ranking:     handlers.NewRankingHandler(rankingSvc),
+		Series:      handlers.NewSeriesHandler(seriesSvc),
 	}
 
 	// 7. 設定路由
 	r := router.Setup(cfg, h, cfg.UploadDir)
 
 	addr := ":" + cfg.Port
-	log.Printf("PaulFun Blogger Go server 啟動於 %s", addr)
-	if err := r.Run(addr); err != nil {
-		log.Fatalf("Server 啟動失敗: %v", err)
+	srv := &http.Server{Addr: addr, Handler: r}
+	go func() {
+		log.Printf("PaulFun Blogger Go server 啟動於 %s", addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Server 啟動失敗: %v", err)
+		}
+	}()
+
+	// 8. 收到 SIGINT / SIGTERM 時停止收請求，並寫完佇列中的瀏覽數與分析事件
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	log.Println("收到停止訊號，關閉中…")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Server 關閉失敗: %v", err)
 	}
+	viewTracker.Close()
+	analyticsSvc.Close()
 }
@@ -0,0 +1,88 @@
+// Package analytics 把讀者端 beacon 的原始欄位整理成可彙總的粗粒度維度。
+//
+// 不保留完整網址、IP 或 User-Agent：referrer 只留主機名稱，UA 只留裝置類型。
+// 彙總與寫入由 services.AnalyticsService 處理。
+package analytics
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// 裝置類型
+const (
+	DeviceDesktop = "desktop"
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+)
+
+// ReferrerInternal 站內導覽（referrer 為本站）；直接造訪（無 referrer）以空字串表示。
+const ReferrerInternal = "(internal)"
+
+// MaxDimensionLen 各維度欄位長度上限（與資料表欄位一致）。
+const MaxDimensionLen = 100
+
+var (
+	tabletUA = regexp.MustCompile(`(?i)ipad|tablet|kindle|silk|playbook`)
+	mobileUA = regexp.MustCompile(`(?i)mobi|iphone|ipod|windows phone|blackberry|opera mini`)
+)
+
+// DeviceClass 由 User-Agent 粗分為 desktop / mobile / tablet。
+// Android 平板的 UA 不含 "Mobile"，以此與手機區分。
+func DeviceClass(userAgent string) string {
+	android := strings.Contains(strings.ToLower(userAgent), "android")
+	switch {
+	case tabletUA.MatchString(userAgent):
+		return DeviceTablet
+	case mobileUA.MatchString(userAgent):
+		return DeviceMobile
+	case android:
+		return DeviceTablet
+	default:
+		return DeviceDesktop
+	}
+}
+
+// ReferrerHost 取出 referrer 的主機名稱（小寫、去掉 port 與 www.）。
+// 無法解析或空白時回空字串（視為直接造訪）；與 siteHost 相同時回 ReferrerInternal。
+func ReferrerHost(referrer, siteHost string) string {
+	referrer = strings.TrimSpace(referrer)
+	if referrer == "" {
+		return ""
+	}
+	u, err := url.Parse(referrer)
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+	host := NormalizeHost(u.Hostname())
+	if host == siteHost {
+		return ReferrerInternal
+	}
+	return truncate(host)
+}
+
+// NormalizeHost 小寫並去掉開頭的 www.。
+func NormalizeHost(host string) string {
+	return strings.TrimPrefix(strings.ToLower(host), "www.")
+}
+
+// UTMValue 整理 utm_* 參數：去空白、轉小寫、截斷。
+func UTMValue(v string) string {
+	return truncate(strings.ToLower(strings.TrimSpace(v)))
+}
+
+func truncate(s string) string {
+	if len(s) <= MaxDimensionLen {
+		return s
+	}
+	// 依 rune 邊界截斷，避免切壞 UTF-8
+	n := 0
+	for i := range s {
+		if i > MaxDimensionLen {
+			break
+		}
+		n = i
+	}
+	return s[:n]
+}
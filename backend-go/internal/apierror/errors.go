@@ -2,7 +2,10 @@
 // 讓 Handler 層用 errors.Is 對應到正確的 HTTP 狀態碼。
 package apierror
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrNotFound 資源不存在（→ 404）
@@ -20,3 +23,17 @@ var (
 	// ErrUnauthorized 未登入（→ 422，避免前端 401 interceptor 攔截）
 	ErrUnauthorized = errors.New("unauthorized")
 )
+
+// VersionConflictError 樂觀鎖版本不符（→ 409，附帶目前版本）。
+// errors.Is(err, ErrConflict) 為 true，未特別處理的呼叫端仍會當一般衝突。
+type VersionConflictError struct {
+	Current int
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("版本衝突：資料已被修改（目前版本 %d），請重新載入後再編輯", e.Current)
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return ErrConflict
+}
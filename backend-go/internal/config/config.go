@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -30,6 +31,52 @@ type Config struct {
 	R2SecretAccessKey string
 	R2Bucket         string
 	R2PublicURL      string
+
+	// 背景排程設定
+	SchedulerEnabled       bool // false 時不啟動任何背景 job
+	PublishIntervalSeconds int  // scheduled → published 轉換的檢查間隔
+
+	// 文章歷史版本保留策略
+	ArchiveRetentionEnabled  bool // true 時由背景 job 定期套用保留策略
+	ArchiveRetentionHours    int  // 背景 job 執行間隔（小時）
+	ArchiveKeepLast          int  // 每篇文章最新 N 個版本一律保留
+	ArchiveDailyAfterDays    int  // 超過 X 天的版本每天只保留一個
+	ArchiveCompressAfterDays int  // 超過 Y 天的版本壓縮 content（0 = 不壓縮）
+
+	// 文章垃圾桶
+	TrashRetentionDays int // 刪除的文章在垃圾桶保留天數，過期由背景 job 永久刪除
+
+	// 公開寫入端點（留言、按讚）的垃圾訊息檢查
+	SpamFilterEnabled          bool     // false 時一律放行
+	SpamStrategies             []string // 啟用的策略，依序執行：honeypot,token,links,blocklist,duplicate
+	SpamMinSubmitSeconds       int      // token 策略：取得表單 token 後至少幾秒才可送出
+	SpamMaxLinks               int      // links 策略：內容連結數上限
+	SpamBlocklist              []string // blocklist 策略：封鎖字詞（不分大小寫）
+	SpamDuplicateWindowMinutes int      // duplicate 策略：同篇文章重複提交的判定時間窗
+	SpamLogAllowed             bool     // true 時放行的請求也寫入判定紀錄
+	SpamTokenSecret            string   // 表單 token 簽章金鑰（預設沿用 JWT_SECRET）
+
+	// 匿名讀者識別（按讚去重）
+	ReaderSecret         string // 匿名 cookie 與讀者指紋的 HMAC 金鑰（預設沿用 JWT_SECRET）
+	ReaderSaltHours      int    // IP+UA 雜湊 salt 輪替週期（小時）
+	LikeReconcileMinutes int    // like_count 校正 job 執行間隔（分鐘）
+
+	// 表情回應
+	ReactionTypes string // "key:emoji,..."，順序即前台顯示順序
+
+	// 瀏覽數統計
+	ViewDedupeMinutes int // 同一讀者重複瀏覽同篇文章不重複計算的時間窗（分鐘）
+	ViewFlushSeconds  int // 瀏覽事件批次寫入間隔（秒）
+	ViewBufferSize    int // 瀏覽事件佇列容量，滿了直接丟棄
+
+	// 讀者分析（beacon）
+	AnalyticsEnabled      bool // false 時 beacon 一律忽略
+	AnalyticsFlushSeconds int  // 彙總批次寫入間隔（秒）
+
+	// 熱門 / 趨勢文章
+	RankingRefreshMinutes int     // 排行快取有效期與背景重算間隔（分鐘）
+	TrendingHalfLifeHours int     // 趨勢分數半衰期（小時）
+	TrendingLikeWeight    float64 // 一個讚相當於幾次瀏覽
 }
 
 func Load() *Config {
@@ -37,6 +84,17 @@ func Load() *Config {
 	_ = godotenv.Load()
 
 	expireHours, _ := strconv.Atoi(getEnv("JWT_EXPIRE_HOURS", "24"))
+	schedulerEnabled, _ := strconv.ParseBool(getEnv("SCHEDULER_ENABLED", "true"))
+	publishInterval := getEnvInt("PUBLISH_INTERVAL_SECONDS", 60)
+	archiveRetentionEnabled, _ := strconv.ParseBool(getEnv("ARCHIVE_RETENTION_ENABLED", "false"))
+	spamFilterEnabled, _ := strconv.ParseBool(getEnv("SPAM_FILTER_ENABLED", "true"))
+	analyticsEnabled, _ := strconv.ParseBool(getEnv("ANALYTICS_ENABLED", "true"))
+	trendingLikeWeight, err := strconv.ParseFloat(getEnv("TRENDING_LIKE_WEIGHT", "5"), 64)
+	if err != nil {
+		trendingLikeWeight = 5
+	}
+	spamLogAllowed, _ := strconv.ParseBool(getEnv("SPAM_LOG_ALLOWED", "false"))
+	jwtSecret := getEnv("JWT_SECRET", "default-secret-change-in-production")
 
 	return &Config{
 		DBHost:     getEnv("DB_HOST", "localhost"),
@@ -46,7 +104,7 @@ func Load() *Config {
 		DBName:     getEnv("DB_NAME", "paulfun_blogger"),
 		DBSSLMode:  getEnv("DB_SSLMODE", "disable"),
 
-		JWTSecret:      getEnv("JWT_SECRET", "default-secret-change-in-production"),
+		JWTSecret:      jwtSecret,
 		JWTExpireHours: expireHours,
 
 		Port:      getEnv("PORT", "8080"),
@@ -59,6 +117,43 @@ func Load() *Config {
 		R2SecretAccessKey: getEnv("R2_SECRET_ACCESS_KEY", ""),
 		R2Bucket:         getEnv("R2_BUCKET", "paulfun-images"),
 		R2PublicURL:      getEnv("R2_PUBLIC_URL", ""),
+
+		SchedulerEnabled:       schedulerEnabled,
+		PublishIntervalSeconds: publishInterval,
+
+		ArchiveRetentionEnabled:  archiveRetentionEnabled,
+		ArchiveRetentionHours:    getEnvInt("ARCHIVE_RETENTION_INTERVAL_HOURS", 24),
+		ArchiveKeepLast:          getEnvNonNegInt("ARCHIVE_KEEP_LAST", 20),
+		ArchiveDailyAfterDays:    getEnvNonNegInt("ARCHIVE_DAILY_AFTER_DAYS", 30),
+		ArchiveCompressAfterDays: getEnvNonNegInt("ARCHIVE_COMPRESS_AFTER_DAYS", 0),
+
+		TrashRetentionDays: getEnvInt("TRASH_RETENTION_DAYS", 30),
+
+		SpamFilterEnabled:          spamFilterEnabled,
+		SpamStrategies:             getEnvList("SPAM_STRATEGIES", "honeypot,links,blocklist,duplicate"),
+		SpamMinSubmitSeconds:       getEnvInt("SPAM_MIN_SUBMIT_SECONDS", 3),
+		SpamMaxLinks:               getEnvInt("SPAM_MAX_LINKS", 2),
+		SpamBlocklist:              getEnvList("SPAM_BLOCKLIST", ""),
+		SpamDuplicateWindowMinutes: getEnvInt("SPAM_DUPLICATE_WINDOW_MINUTES", 60),
+		SpamLogAllowed:             spamLogAllowed,
+		SpamTokenSecret:            getEnv("SPAM_TOKEN_SECRET", jwtSecret),
+
+		ReaderSecret:         getEnv("READER_SECRET", jwtSecret),
+		ReaderSaltHours:      getEnvInt("READER_SALT_HOURS", 24),
+		LikeReconcileMinutes: getEnvInt("LIKE_RECONCILE_INTERVAL_MINUTES", 60),
+
+		ReactionTypes: getEnv("REACTION_TYPES", "thumbsup:👍,heart:❤️,thinking:🤔,tada:🎉"),
+
+		ViewDedupeMinutes: getEnvInt("VIEW_DEDUPE_MINUTES", 30),
+		ViewFlushSeconds:  getEnvInt("VIEW_FLUSH_INTERVAL_SECONDS", 10),
+		ViewBufferSize:    getEnvInt("VIEW_BUFFER_SIZE", 4096),
+
+		AnalyticsEnabled:      analyticsEnabled,
+		AnalyticsFlushSeconds: getEnvInt("ANALYTICS_FLUSH_INTERVAL_SECONDS", 30),
+
+		RankingRefreshMinutes: getEnvInt("RANKING_REFRESH_MINUTES", 10),
+		TrendingHalfLifeHours: getEnvInt("TRENDING_HALF_LIFE_HOURS", 48),
+		TrendingLikeWeight:    trendingLikeWeight,
 	}
 }
 
@@ -75,3 +170,32 @@ func getEnv(key, defaultVal string) string {
 	}
 	return defaultVal
 }
+
+// getEnvInt 讀取正整數設定；未設定或格式錯誤時回傳預設值。
+func getEnvInt(key string, defaultVal int) int {
+	n, err := strconv.Atoi(getEnv(key, ""))
+	if err != nil || n <= 0 {
+		return defaultVal
+	}
+	return n
+}
+
+// getEnvNonNegInt 讀取允許 0 的整數設定（0 有意義時使用）；未設定、格式錯誤或負數時回傳預設值。
+func getEnvNonNegInt(key string, defaultVal int) int {
+	n, err := strconv.Atoi(getEnv(key, ""))
+	if err != nil || n < 0 {
+		return defaultVal
+	}
+	return n
+}
+
+// getEnvList 讀取逗號分隔的清單，略過空白項目。
+func getEnvList(key, defaultVal string) []string {
+	var out []string
+	for _, item := range strings.Split(getEnv(key, defaultVal), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
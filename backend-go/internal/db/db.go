@@ -18,6 +18,9 @@ func Init(cfg *config.Config) *gorm.DB {
 		log.Fatalf("無法連線到資料庫: %v", err)
 	}
 
+	// article_likes 第一次建立時，既有的 like_count 轉為 like_base（見 seedLikeBase）
+	seedLikes := !db.Migrator().HasTable(&models.ArticleLike{})
+
 	// AutoMigrate：依照 models 自動建立/更新資料表
 	if err := db.AutoMigrate(
 		&models.User{},
@@ -28,10 +31,77 @@ func Init(cfg *config.Config) *gorm.DB {
 		&models.Media{},
 		&models.ServiceAccountToken{},
 		&models.ArticleLink{},
+		&models.ArticlePublishLog{},
+		&models.ArticleSlugHistory{},
+		&models.Comment{},
+		&models.SpamDecision{},
+		&models.ArticleLike{},
+		&models.ArticleReaction{},
+		&models.ArticleStat{},
+		&models.PageViewDaily{},
+		&models.Series{},
+		&models.SeriesArticle{},
 	); err != nil {
 		log.Fatalf("AutoMigrate 失敗: %v", err)
 	}
 
+	if err := ensureArticleSearchIndex(db); err != nil {
+		log.Fatalf("建立全文檢索索引失敗: %v", err)
+	}
+
+	if seedLikes {
+		if err := seedLikeBase(db); err != nil {
+			log.Fatalf("初始化 like_base 失敗: %v", err)
+		}
+	}
+
+	if err := migrateSeriesLinks(db); err != nil {
+		log.Fatalf("轉換 series 串連失敗: %v", err)
+	}
+
 	log.Println("資料庫連線成功，Migration 完成")
 	return db
 }
+
+// ensureArticleSearchIndex 建立 articles.search_vector 欄位與 GIN index，
+// 並重算尚未建立或建立規則較舊（search_version）的 row。
+// 欄位由 models.Article 的 AfterSave hook 維護（不在 struct 上，AutoMigrate 不會處理）。
+func ensureArticleSearchIndex(db *gorm.DB) error {
+	stmts := []string{
+		`ALTER TABLE articles ADD COLUMN IF NOT EXISTS search_vector tsvector`,
+		`ALTER TABLE articles ADD COLUMN IF NOT EXISTS search_version smallint NOT NULL DEFAULT 0`,
+		`CREATE INDEX IF NOT EXISTS idx_articles_search_vector ON articles USING GIN (search_vector)`,
+	}
+	for _, stmt := range stmts {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+
+	var ids []uint
+	if err := db.Table("articles").
+		Where("search_version < ?", models.ArticleSearchIndexVersion).
+		Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := models.UpdateArticleSearchVector(db, id); err != nil {
+			return err
+		}
+	}
+	if len(ids) > 0 {
+		log.Printf("全文檢索索引重建 %d 篇文章", len(ids))
+	}
+	return nil
+}
+
+// seedLikeBase 去重機制上線前的讚沒有逐筆紀錄，整批保留為 like_base，
+// 之後 like_count = like_base + article_likes 筆數。只在 article_likes 首次建立時執行一次。
+func seedLikeBase(db *gorm.DB) error {
+	res := db.Exec("UPDATE articles SET like_base = like_count WHERE like_count > 0")
+	if res.Error != nil {
+		return res.Error
+	}
+	log.Printf("like_base 已初始化（%d 篇文章）", res.RowsAffected)
+	return nil
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"gorm.io/gorm"
+)
+
+// migrateSeriesLinks 把舊的 ArticleLink series 鏈（from 承接 to，新 → 舊）轉成 Series + SeriesArticle，
+// 轉換完成的 link 同一個 transaction 內刪除，因此每次啟動執行都是冪等的（沒有 series link 就什麼都不做）。
+//
+//   - 系列標題沿用最舊那篇的標題，slug 為 series-{最舊文章 ID}，之後可在後台修改
+//   - link 的 note 標在較新那篇（from），轉為該篇的 SeriesArticle.Note
+//   - 資料異常（分岔、成環、文章已在其他系列）時保留先走到的部分，其餘 link 一併刪除並記 log
+func migrateSeriesLinks(db *gorm.DB) error {
+	var links []models.ArticleLink
+	if err := db.Where("relation = ?", models.LinkRelationSeries).Order("id").Find(&links).Error; err != nil {
+		return err
+	}
+	if len(links) == 0 {
+		return nil
+	}
+
+	newerOf := map[uint]uint{} // to → from
+	hasOlder := map[uint]bool{}
+	noteOf := map[uint]*string{}
+	for _, l := range links {
+		if _, dup := newerOf[l.ToArticleID]; dup {
+			log.Printf("[series] 文章 %d 有多篇承接文章，略過 link %d", l.ToArticleID, l.ID)
+			continue
+		}
+		newerOf[l.ToArticleID] = l.FromArticleID
+		hasOlder[l.FromArticleID] = true
+		noteOf[l.FromArticleID] = l.Note
+	}
+
+	// 鏈頭 = 被承接但自己不承接任何文章的最舊那篇
+	var heads []uint
+	for to := range newerOf {
+		if !hasOlder[to] {
+			heads = append(heads, to)
+		}
+	}
+	sort.Slice(heads, func(i, j int) bool { return heads[i] < heads[j] })
+
+	var existing []uint
+	if err := db.Model(&models.SeriesArticle{}).Pluck("article_id", &existing).Error; err != nil {
+		return err
+	}
+	assigned := map[uint]bool{}
+	for _, id := range existing {
+		assigned[id] = true
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		created := 0
+		for _, head := range heads {
+			chain := []uint{}
+			for cur, ok := head, true; ok && !assigned[cur]; cur, ok = newerOf[cur] {
+				assigned[cur] = true
+				chain = append(chain, cur)
+			}
+			if len(chain) < 2 {
+				continue
+			}
+
+			var oldest models.Article
+			if err := tx.Unscoped().Select("id", "title").First(&oldest, head).Error; err != nil {
+				log.Printf("[series] 鏈頭文章 %d 不存在，略過", head)
+				continue
+			}
+			series := models.Series{Title: oldest.Title, Slug: fmt.Sprintf("series-%d", head)}
+			if err := tx.Create(&series).Error; err != nil {
+				return err
+			}
+			members := make([]models.SeriesArticle, len(chain))
+			for i, id := range chain {
+				members[i] = models.SeriesArticle{SeriesID: series.ID, ArticleID: id, Position: i + 1, Note: noteOf[id]}
+			}
+			if err := tx.Create(&members).Error; err != nil {
+				return err
+			}
+			created++
+		}
+
+		if err := tx.Where("relation = ?", models.LinkRelationSeries).Delete(&models.ArticleLink{}).Error; err != nil {
+			return err
+		}
+		log.Printf("[series] 已將 %d 筆 series 串連轉為 %d 個系列", len(links), created)
+		return nil
+	})
+}
@@ -0,0 +1,103 @@
+package dto
+
+// ── 讀者分析（/api/analytics/beacon、/api/admin/analytics）──────────
+
+// BeaconRequest 前台頁面載入時送出的 beacon（navigator.sendBeacon，Content-Type 可能是 text/plain）。
+// Referrer 為 document.referrer；UTM 參數取自目前網址。
+type BeaconRequest struct {
+	ArticleID   uint   `json:"articleId" binding:"required"`
+	Referrer    string `json:"referrer"`
+	UTMSource   string `json:"utmSource"`
+	UTMMedium   string `json:"utmMedium"`
+	UTMCampaign string `json:"utmCampaign"`
+}
+
+// AnalyticsRangeParams 後台分析查詢的日期區間（UTC，YYYY-MM-DD，含頭尾）。
+// 未指定時為最近 30 天；區間上限 366 天。
+type AnalyticsRangeParams struct {
+	From string `form:"from"`
+	To   string `form:"to"`
+}
+
+// TopArticlesQueryParams 熱門文章排行查詢參數。
+type TopArticlesQueryParams struct {
+	AnalyticsRangeParams
+	Limit int `form:"limit"` // 預設 20，上限 100
+}
+
+func (q *TopArticlesQueryParams) GetLimit() int {
+	if q.Limit < 1 || q.Limit > 100 {
+		return 20
+	}
+	return q.Limit
+}
+
+// TopSourcesQueryParams 來源排行查詢參數。
+type TopSourcesQueryParams struct {
+	AnalyticsRangeParams
+	By        string `form:"by"`        // referrer（預設）| source | medium | campaign | device
+	ArticleID uint   `form:"articleId"` // 只看單篇文章（0 = 全站）
+	Limit     int    `form:"limit"`     // 預設 20，上限 100
+}
+
+func (q *TopSourcesQueryParams) GetLimit() int {
+	if q.Limit < 1 || q.Limit > 100 {
+		return 20
+	}
+	return q.Limit
+}
+
+// AnalyticsRangeDto 實際套用的日期區間。
+type AnalyticsRangeDto struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// TopArticleDto 熱門文章排行的一列。
+type TopArticleDto struct {
+	ArticleID uint   `json:"articleId"`
+	Title     string `json:"title"`
+	Slug      string `json:"slug"`
+	Views     int    `json:"views"`
+	Visitors  int    `json:"visitors"`
+}
+
+// TopArticlesDto 熱門文章排行。
+type TopArticlesDto struct {
+	Range    AnalyticsRangeDto `json:"range"`
+	Articles []TopArticleDto   `json:"articles"`
+}
+
+// SourceStatDto 來源排行的一列。Key 為空字串時 Label 為 "(direct)" / "(none)"。
+type SourceStatDto struct {
+	Key      string `json:"key"`
+	Label    string `json:"label"`
+	Views    int    `json:"views"`
+	Visitors int    `json:"visitors"`
+}
+
+// TopSourcesDto 來源排行。
+type TopSourcesDto struct {
+	Range   AnalyticsRangeDto `json:"range"`
+	By      string            `json:"by"`
+	Sources []SourceStatDto   `json:"sources"`
+}
+
+// AnalyticsDayDto 單日數字。
+type AnalyticsDayDto struct {
+	Date     string `json:"date"` // YYYY-MM-DD（UTC）
+	Views    int    `json:"views"`
+	Visitors int    `json:"visitors"`
+}
+
+// ArticleAnalyticsDto 單篇文章在區間內的每日數字（無資料的日子補 0）與來源摘要。
+type ArticleAnalyticsDto struct {
+	ArticleID uint              `json:"articleId"`
+	Title     string            `json:"title"`
+	Range     AnalyticsRangeDto `json:"range"`
+	Views     int               `json:"views"`
+	Visitors  int               `json:"visitors"`
+	Days      []AnalyticsDayDto `json:"days"`      // 舊 → 新
+	Referrers []SourceStatDto   `json:"referrers"` // 前 10 名
+	Devices   []SourceStatDto   `json:"devices"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+// ── 歷史版本保留策略（POST /api/admin/archives/prune）──────────────
+
+// ArchivePruneRequest 手動套用保留策略。策略欄位省略時使用伺服器設定值。
+type ArchivePruneRequest struct {
+	DryRun            *bool `json:"dryRun"`            // 預設 true：只回報不執行
+	ArticleID         *uint `json:"articleId"`         // 只處理單篇文章
+	KeepLast          *int  `json:"keepLast"`          // 每篇文章最新 N 個版本一律保留
+	DailyAfterDays    *int  `json:"dailyAfterDays"`    // 超過 X 天的版本每天只留一個
+	CompressAfterDays *int  `json:"compressAfterDays"` // 超過 Y 天的版本壓縮（0 = 不壓縮）
+}
+
+// ArchiveRetentionPolicyDto 實際套用的保留策略。
+type ArchiveRetentionPolicyDto struct {
+	KeepLast          int `json:"keepLast"`
+	DailyAfterDays    int `json:"dailyAfterDays"`
+	CompressAfterDays int `json:"compressAfterDays"`
+}
+
+// ArchivePruneReportDto 保留策略執行結果（dryRun 時為預估）。
+type ArchivePruneReportDto struct {
+	DryRun          bool                      `json:"dryRun"`
+	Policy          ArchiveRetentionPolicyDto `json:"policy"`
+	ArticlesScanned int                       `json:"articlesScanned"`
+	ArchivesScanned int                       `json:"archivesScanned"`
+	Deleted         int                       `json:"deleted"`         // dryRun 時為「將刪除」
+	Compressed      int                       `json:"compressed"`      // dryRun 時為「將壓縮」
+	BytesToCompress int64                     `json:"bytesToCompress"` // 待壓縮 content 原始大小
+	BytesSaved      int64                     `json:"bytesSaved"`      // 實際節省（dryRun 時為 0）
+	Articles        []ArchivePruneArticleDto  `json:"articles"`        // 只列出有變動的文章
+}
+
+// ArchivePruneArticleDto 單篇文章的處理明細（archive ID 列表）。
+type ArchivePruneArticleDto struct {
+	ArticleID uint   `json:"articleId"`
+	Delete    []uint `json:"delete"`
+	Compress  []uint `json:"compress"`
+}
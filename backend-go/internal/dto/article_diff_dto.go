@@ -0,0 +1,65 @@
+package dto
+
+import "time"
+
+// ── 版本差異（GET /api/admin/articles/:id/archives/diff）──────────
+
+// ArticleDiffDto 兩個版本之間的結構化差異。
+type ArticleDiffDto struct {
+	ArticleID uint                 `json:"articleId"`
+	From      ArticleVersionRefDto `json:"from"`
+	To        ArticleVersionRefDto `json:"to"`
+	Fields    []FieldChangeDto     `json:"fields"` // 只列出有變動的欄位
+	Tags      TagSetDiffDto        `json:"tags"`
+	Content   ContentDiffDto       `json:"content"`
+}
+
+// ArticleVersionRefDto 比對的一端：某個 archive 或目前版本。
+type ArticleVersionRefDto struct {
+	Ref       string     `json:"ref"` // archive ID 或 "current"
+	Version   int        `json:"version"`
+	Status    string     `json:"status"`
+	Timestamp *time.Time `json:"timestamp"` // archive 為 archivedAt；current 為 updatedAt（未曾更新則 createdAt）
+}
+
+// FieldChangeDto 單一欄位變動。category 欄位的 from / to 為 CategoryRefDto。
+type FieldChangeDto struct {
+	Field string `json:"field"` // title | slug | summary | coverImage | category
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// CategoryRefDto diff 中的分類參照（分類已刪除時 name 為空）。
+type CategoryRefDto struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// TagSetDiffDto 標籤集合差異（標籤已刪除時 name / slug 為空）。
+type TagSetDiffDto struct {
+	Added     []TagDto `json:"added"`
+	Removed   []TagDto `json:"removed"`
+	Unchanged []TagDto `json:"unchanged"`
+}
+
+// ContentDiffDto 內文差異：HTML 去標籤後逐行比對，修改的行再逐詞比對。
+type ContentDiffDto struct {
+	Changed      bool          `json:"changed"`
+	LinesAdded   int           `json:"linesAdded"`
+	LinesRemoved int           `json:"linesRemoved"`
+	LinesChanged int           `json:"linesChanged"`
+	Lines        []DiffLineDto `json:"lines"`
+}
+
+// DiffLineDto 一行的差異。
+type DiffLineDto struct {
+	Op       string           `json:"op"`                 // equal | insert | delete | replace
+	Text     string           `json:"text,omitempty"`     // equal / insert / delete 的行內容
+	Segments []DiffSegmentDto `json:"segments,omitempty"` // replace：逐詞差異
+}
+
+// DiffSegmentDto 行內逐詞差異片段。
+type DiffSegmentDto struct {
+	Op   string `json:"op"` // equal | insert | delete
+	Text string `json:"text"`
+}
@@ -14,9 +14,10 @@ type CategoryDto struct {
 }
 
 type TagDto struct {
-	ID   uint   `json:"id"`
-	Name string `json:"name"`
-	Slug string `json:"slug"`
+	ID           uint   `json:"id"`
+	Name         string `json:"name"`
+	Slug         string `json:"slug"`
+	ArticleCount int    `json:"articleCount,omitempty"`
 }
 
 // ── Article ───────────────────────────────────────────────────
@@ -41,6 +42,8 @@ type ArticleDto struct {
 	Tags      []TagDto   `json:"tags"`
 	CreatedAt time.Time  `json:"createdAt"`
 	UpdatedAt *time.Time `json:"updatedAt"`
+	// Reactions 各表情回應數（依設定順序）；只有查詢單篇文章時帶。
+	Reactions []ReactionCountDto `json:"reactions,omitempty"`
 }
 
 type ArticleListItemDto struct {
@@ -72,27 +75,35 @@ type CreateArticleRequest struct {
 
 type UpdateArticleRequest struct {
 	Title      string  `json:"title" binding:"required"`
+	Slug       *string `json:"slug"` // 選填；nil = 不變，改動時舊 slug 保留轉址
 	Summary    *string `json:"summary"`
 	Content    *string `json:"content"`
 	CoverImage *string `json:"coverImage"`
 	CategoryID *uint   `json:"categoryId"`
 	TagIDs     []uint  `json:"tagIds"`
+	// ExpectedVersion 樂觀鎖：client 編輯時看到的版本；不符回 409。
+	// 未傳時改讀 If-Match header；兩者皆無則不檢查（相容舊 client）。
+	ExpectedVersion *int `json:"expectedVersion"`
 }
 
 // PatchArticleRequest 支援單一欄位更新。
 // 搭配 PatchArticleFields 使用，透過 json.RawMessage 判斷哪些欄位有傳送。
 type PatchArticleRequest struct {
 	Title      *string `json:"title"`
+	Slug       *string `json:"slug"`
 	Summary    *string `json:"summary"`
 	Content    *string `json:"content"`
 	CoverImage *string `json:"coverImage"`
 	CategoryID *uint   `json:"categoryId"`
 	TagIDs     []uint  `json:"tagIds"`
+	// ExpectedVersion 同 UpdateArticleRequest.ExpectedVersion。
+	ExpectedVersion *int `json:"expectedVersion"`
 }
 
 // PatchArticleFields 記錄哪些欄位在 JSON 中有明確傳送（包含 null）。
 type PatchArticleFields struct {
 	HasTitle      bool
+	HasSlug       bool
 	HasSummary    bool
 	HasContent    bool
 	HasCoverImage bool
@@ -127,6 +138,17 @@ type ArticleArchiveDetailDto struct {
 	ArchivedBy uint      `json:"archivedBy"`
 }
 
+// VersionConflictDto 409 版本衝突回應的 data。
+type VersionConflictDto struct {
+	CurrentVersion int `json:"currentVersion"`
+}
+
+// SlugRedirectDto 以舊 slug 查詢時的轉址資訊（搭配 301 回應）。
+type SlugRedirectDto struct {
+	ID   uint   `json:"id"`
+	Slug string `json:"slug"` // 目前的 slug
+}
+
 type PublishArticleRequest struct {
 	ScheduledAt *time.Time `json:"scheduledAt"`
 }
@@ -144,6 +166,16 @@ type ArticleQueryParams struct {
 	TagID      *uint  `form:"tagId"`
 	Search     string `form:"search"`
 	Status     string `form:"status"`
+
+	// Category 以 slug 指定分類（與 categoryId 擇一；不存在 → 404）。
+	Category string `form:"category"`
+	// IncludeDescendants 分類篩選是否包含所有子孫分類。
+	IncludeDescendants bool `form:"includeDescendants"`
+	// TagIDs / Tags 多標籤（csv，ID 或 slug，可與 tagId 併用）；不存在的 slug → 404。
+	TagIDs string `form:"tagIds"`
+	Tags   string `form:"tags"`
+	// TagMode any（預設，命中任一標籤）| all（須同時具備所有標籤）。
+	TagMode string `form:"tagMode"`
 }
 
 func (q *ArticleQueryParams) GetPage() int {
@@ -7,7 +7,7 @@ type ArticleSearchParams struct {
 	Q          string `form:"q"`          // 多關鍵字，空白分隔
 	Mode       string `form:"mode"`       // and（預設）| or
 	Fields     string `form:"fields"`     // csv: title,summary,content（預設全部）
-	Status     string `form:"status"`     // draft | scheduled | published（預設全部）
+	Status     string `form:"status"`     // draft | scheduled | published（預設全部；前台 /api/articles/search 忽略）
 	CategoryIDs string `form:"categoryIds"` // csv
 	TagIDs     string `form:"tagIds"`     // csv
 	DateField  string `form:"dateField"`  // created（預設）| published
@@ -15,7 +15,7 @@ type ArticleSearchParams struct {
 	DateTo     string `form:"dateTo"`     // YYYY-MM-DD
 	Page       int    `form:"page"`
 	PageSize   int    `form:"pageSize"`
-	SortBy     string `form:"sortBy"`
+	SortBy     string `form:"sortBy"`     // createdAt（預設）| title | publishedAt | viewCount | relevance
 	Descending *bool  `form:"descending"`
 }
 
@@ -26,11 +26,33 @@ type ArticleSearchItemDto struct {
 	MatchedFields []string `json:"matchedFields"` // 命中的欄位
 }
 
+// ArticleSearchResponseDto 檢索回應：分頁結果（欄位攤平，與 PagedResponse 相容）+ facets。
+type ArticleSearchResponseDto struct {
+	PagedResponse[ArticleSearchItemDto]
+	Facets SearchFacetsDto `json:"facets"`
+}
+
+// SearchFacetsDto 目前查詢條件（含所有篩選）下各維度的命中筆數，依筆數多 → 少排序。
+type SearchFacetsDto struct {
+	Categories    []FacetCountDto `json:"categories"`    // key = 分類 slug（未設定分類時 id 為 null）
+	Tags          []FacetCountDto `json:"tags"`          // key = 標籤 slug
+	Statuses      []FacetCountDto `json:"statuses"`      // key = draft | scheduled | published
+	PublishMonths []FacetCountDto `json:"publishMonths"` // key = YYYY-MM（UTC），新 → 舊；未發佈者不計
+}
+
+// FacetCountDto 單一 facet 值的筆數。
+type FacetCountDto struct {
+	ID    *uint  `json:"id,omitempty"`
+	Key   string `json:"key"`
+	Name  string `json:"name,omitempty"`
+	Count int    `json:"count"`
+}
+
 // ── 知識串連 ────────────────────────────────────────────
 
 type CreateArticleLinkRequest struct {
 	ToArticleID uint    `json:"toArticleId" binding:"required"`
-	Relation    string  `json:"relation" binding:"required"` // related | series
+	Relation    string  `json:"relation" binding:"required"` // related（系列改由 /api/admin/series 管理）
 	Note        *string `json:"note"`
 }
 
@@ -64,6 +86,22 @@ type SeriesItemDto struct {
 
 // RelatedArticlesDto 公開端 GET /api/articles/:id/related 回應。
 type RelatedArticlesDto struct {
-	Series  []SeriesItemDto  `json:"series"`  // 完整系列鏈，舊 → 新
+	Series  []SeriesItemDto  `json:"series"`  // 所屬系列，依系列順序
+	SeriesInfo *SeriesRefDto `json:"seriesInfo"` // 所屬系列本身（無系列時為 null）
 	Related []SeriesItemDto  `json:"related"` // 相關文章（雙向去重）
+	Suggested []SuggestedArticleDto `json:"suggested"` // 內容相似度推薦（不含上面已列出的文章）
+}
+
+// SuggestedArticleDto 內容相似度推薦的文章，Score 介於 0 到 1。
+type SuggestedArticleDto struct {
+	ID          uint    `json:"id"`
+	Title       string  `json:"title"`
+	Slug        string  `json:"slug"`
+	PublishedAt *string `json:"publishedAt"`
+	Score       float64 `json:"score"`
+}
+
+// PromoteSuggestionRequest 把推薦轉為 related 串連（body 可省略）。
+type PromoteSuggestionRequest struct {
+	Note *string `json:"note"`
 }
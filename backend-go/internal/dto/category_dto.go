@@ -25,3 +25,30 @@ type UpdateCategoryRequest struct {
 	ParentID  *uint  `json:"parentId"`
 	SortOrder int    `json:"sortOrder"`
 }
+
+// ReorderCategoriesRequest POST /api/admin/categories/reorder
+//
+// 一次設定多個分類的 parent 與排序（拖拉排序後整批送出）；未列出的分類不變。
+// 全部驗證通過才寫入（同一個 transaction）。
+type ReorderCategoriesRequest struct {
+	Items []ReorderCategoryItem `json:"items" binding:"required,min=1,dive"`
+}
+
+// ReorderCategoryItem 單一分類的新位置。parentId nil = 頂層。
+type ReorderCategoryItem struct {
+	ID        uint  `json:"id" binding:"required"`
+	ParentID  *uint `json:"parentId"`
+	SortOrder int   `json:"sortOrder"`
+}
+
+// CategoryTreeNodeDto GET /api/articles/categories/tree 的節點。
+type CategoryTreeNodeDto struct {
+	ID                uint                  `json:"id"`
+	Name              string                `json:"name"`
+	Slug              string                `json:"slug"`
+	ParentID          *uint                 `json:"parentId"`
+	SortOrder         int                   `json:"sortOrder"`
+	ArticleCount      int                   `json:"articleCount"`      // 直屬此分類的已發佈文章數
+	TotalArticleCount int                   `json:"totalArticleCount"` // 含所有子孫分類
+	Children          []CategoryTreeNodeDto `json:"children"`
+}
@@ -0,0 +1,96 @@
+package dto
+
+import "time"
+
+// ── 留言（/api/articles/:id/comments、/api/admin/comments）──────────
+
+// CreateCommentRequest 讀者送出留言 / 回覆。
+type CreateCommentRequest struct {
+	AuthorName  string  `json:"authorName" binding:"required,max=50"`
+	AuthorEmail *string `json:"authorEmail" binding:"omitempty,email,max=200"`
+	Content     string  `json:"content" binding:"required,max=2000"`
+	ParentID    *uint   `json:"parentId"` // 回覆對象；省略 = 頂層留言
+	SpamFieldsDto
+}
+
+// CommentQueryParams 前台留言列表（以頂層留言分頁，回覆全部巢狀帶出）。
+type CommentQueryParams struct {
+	Page     int `form:"page"`
+	PageSize int `form:"pageSize"`
+}
+
+func (q *CommentQueryParams) GetPage() int {
+	if q.Page < 1 {
+		return 1
+	}
+	return q.Page
+}
+
+func (q *CommentQueryParams) GetPageSize() int {
+	if q.PageSize < 1 || q.PageSize > 100 {
+		return 20
+	}
+	return q.PageSize
+}
+
+// CommentDto 前台留言（不含 email / IP）。
+type CommentDto struct {
+	ID         uint         `json:"id"`
+	ParentID   *uint        `json:"parentId"`
+	AuthorName string       `json:"authorName"`
+	Content    string       `json:"content"`
+	CreatedAt  time.Time    `json:"createdAt"`
+	Status     string       `json:"status,omitempty"` // 只在送出留言的回應中帶，讓讀者知道是否需等待審核
+	Replies    []CommentDto `json:"replies"`
+}
+
+// AdminCommentQueryParams 後台審核佇列查詢參數。
+type AdminCommentQueryParams struct {
+	Status    string `form:"status"`    // pending（預設）| approved | rejected | spam | all
+	ArticleID uint   `form:"articleId"` // 0 = 不限
+	Page      int    `form:"page"`
+	PageSize  int    `form:"pageSize"`
+}
+
+func (q *AdminCommentQueryParams) GetStatus() string {
+	if q.Status == "" {
+		return "pending"
+	}
+	return q.Status
+}
+
+func (q *AdminCommentQueryParams) GetPage() int {
+	if q.Page < 1 {
+		return 1
+	}
+	return q.Page
+}
+
+func (q *AdminCommentQueryParams) GetPageSize() int {
+	if q.PageSize < 1 || q.PageSize > 100 {
+		return 20
+	}
+	return q.PageSize
+}
+
+// AdminCommentDto 後台審核用的完整留言資料。
+type AdminCommentDto struct {
+	ID           uint       `json:"id"`
+	ArticleID    uint       `json:"articleId"`
+	ArticleTitle string     `json:"articleTitle"`
+	ParentID     *uint      `json:"parentId"`
+	AuthorName   string     `json:"authorName"`
+	AuthorEmail  *string    `json:"authorEmail"`
+	Content      string     `json:"content"`
+	Status       string     `json:"status"`
+	IPAddress    string     `json:"ipAddress"`
+	UserAgent    string     `json:"userAgent"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	ModeratedAt  *time.Time `json:"moderatedAt"`
+	ModeratedBy  *uint      `json:"moderatedBy"`
+}
+
+// CommentDeleteResultDto 刪除留言結果（連同底下的回覆一起刪除）。
+type CommentDeleteResultDto struct {
+	Deleted []uint `json:"deleted"`
+}
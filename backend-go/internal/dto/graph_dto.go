@@ -0,0 +1,45 @@
+package dto
+
+// ── 知識圖譜匯出（/api/admin/graph）────────────────────────────────
+
+// GraphQueryParams 匯出與統計共用的篩選條件。
+//
+// 分類 / 標籤篩選的是節點（文章），邊只保留兩端都在結果中的；
+// 分類 / 標籤參數與文章列表相同（category / categoryId / includeDescendants / tags / tagIds / tagMode）。
+type GraphQueryParams struct {
+	Format      string `form:"format"`      // json（預設）| graphml | dot；只用於匯出
+	Relation    string `form:"relation"`    // related | series（空 = 全部）
+	Status      string `form:"status"`      // 文章狀態（空 = 全部，不含垃圾桶）
+	HideOrphans bool   `form:"hideOrphans"` // 匯出時略過沒有任何串連的文章
+
+	CategoryID         *uint  `form:"categoryId"`
+	Category           string `form:"category"`
+	IncludeDescendants bool   `form:"includeDescendants"`
+	TagIDs             string `form:"tagIds"`
+	Tags               string `form:"tags"`
+	TagMode            string `form:"tagMode"`
+}
+
+// GraphStatsDto 圖統計。
+type GraphStatsDto struct {
+	NodeCount      int                 `json:"nodeCount"`
+	EdgeCount      int                 `json:"edgeCount"`
+	EdgesByType    map[string]int      `json:"edgesByType"`
+	OrphanCount    int                 `json:"orphanCount"`
+	Orphans        []GraphNodeRefDto   `json:"orphans"`        // 沒有任何串連的文章（最多 100 筆）
+	ComponentCount int                 `json:"componentCount"` // 連通分量數（含孤立文章）
+	Largest        []GraphComponentDto `json:"largestComponents"`
+}
+
+// GraphNodeRefDto 統計結果中的文章。
+type GraphNodeRefDto struct {
+	ID     uint   `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+}
+
+// GraphComponentDto 一個連通分量（前 10 大，不含孤立文章）。
+type GraphComponentDto struct {
+	Size     int               `json:"size"`
+	Articles []GraphNodeRefDto `json:"articles"`
+}
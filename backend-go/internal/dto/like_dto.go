@@ -0,0 +1,7 @@
+package dto
+
+// LikeStatusDto 文章讚數與目前讀者是否已按讚。
+type LikeStatusDto struct {
+	LikeCount int  `json:"likeCount"`
+	Liked     bool `json:"liked"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+// ── 熱門 / 趨勢文章（/api/articles/popular、/api/articles/trending）──
+
+// PopularQueryParams 熱門文章查詢參數。
+type PopularQueryParams struct {
+	Window string `form:"window"` // 7d（預設）| 30d | all
+	Limit  int    `form:"limit"`  // 預設 10，上限 50
+}
+
+func (q *PopularQueryParams) GetLimit() int {
+	return rankingLimit(q.Limit)
+}
+
+// TrendingQueryParams 趨勢文章查詢參數。
+type TrendingQueryParams struct {
+	Limit int `form:"limit"` // 預設 10，上限 50
+}
+
+func (q *TrendingQueryParams) GetLimit() int {
+	return rankingLimit(q.Limit)
+}
+
+func rankingLimit(limit int) int {
+	if limit < 1 || limit > 50 {
+		return 10
+	}
+	return limit
+}
+
+// RankedArticleDto 排行中的一篇文章。Score 依排行而異：popular 為區間瀏覽數，trending 為衰減後分數。
+type RankedArticleDto struct {
+	ArticleListItemDto
+	Score float64 `json:"score"`
+}
+
+// ArticleRankingDto 排行結果。GeneratedAt 為快取計算時間。
+type ArticleRankingDto struct {
+	Window      string             `json:"window"` // 7d | 30d | all | trending
+	GeneratedAt time.Time          `json:"generatedAt"`
+	Items       []RankedArticleDto `json:"items"`
+}
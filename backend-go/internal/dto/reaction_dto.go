@@ -0,0 +1,45 @@
+package dto
+
+// ── 表情回應（/api/articles/:id/reactions、/api/admin/reactions）──────
+
+// ReactionCountDto 單一表情的回應數。
+type ReactionCountDto struct {
+	Type  string `json:"type"` // 設定中的 key，例如 thumbsup
+	Emoji string `json:"emoji"`
+	Count int    `json:"count"`
+}
+
+// ReactionStatusDto 文章各表情回應數與目前讀者已給的回應。
+type ReactionStatusDto struct {
+	Reactions []ReactionCountDto `json:"reactions"` // 依設定順序，含 0
+	Mine      []string           `json:"mine"`
+}
+
+// ReactionStatsQueryParams 後台回應統計查詢參數。
+type ReactionStatsQueryParams struct {
+	Type  string `form:"type"`  // 依此表情數排序（空 = 依總數）
+	Days  int    `form:"days"`  // 只統計最近 N 天（0 = 全部）
+	Limit int    `form:"limit"` // 預設 20，上限 100
+}
+
+func (q *ReactionStatsQueryParams) GetLimit() int {
+	if q.Limit < 1 || q.Limit > 100 {
+		return 20
+	}
+	return q.Limit
+}
+
+// ReactionStatsDto 後台回應統計：各表情總數與文章排行。
+type ReactionStatsDto struct {
+	Totals   []ReactionCountDto        `json:"totals"`
+	Articles []ArticleReactionStatsDto `json:"articles"`
+}
+
+// ArticleReactionStatsDto 單篇文章的回應分布。
+type ArticleReactionStatsDto struct {
+	ArticleID uint               `json:"articleId"`
+	Title     string             `json:"title"`
+	Slug      string             `json:"slug"`
+	Total     int                `json:"total"`
+	Reactions []ReactionCountDto `json:"reactions"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+// SchedulerStatusDto GET /api/admin/scheduler/status 回應。
+type SchedulerStatusDto struct {
+	Jobs              []SchedulerJobDto     `json:"jobs"`
+	Upcoming          []ScheduledArticleDto `json:"upcoming"`          // 即將到期的排程文章，依時間排序
+	RecentTransitions []PublishLogDto       `json:"recentTransitions"` // 最近的發佈狀態轉換
+}
+
+// SchedulerJobDto 單一背景工作的執行狀態。
+type SchedulerJobDto struct {
+	Name            string     `json:"name"`
+	IntervalSeconds int        `json:"intervalSeconds"`
+	Running         bool       `json:"running"`
+	RunCount        int        `json:"runCount"`
+	LastRunAt       *time.Time `json:"lastRunAt"`
+	LastDurationMs  int64      `json:"lastDurationMs"`
+	LastResult      string     `json:"lastResult"` // ok | error | pending（尚未執行）
+	LastMessage     string     `json:"lastMessage"`
+	LastError       string     `json:"lastError,omitempty"`
+	NextRunAt       *time.Time `json:"nextRunAt"`
+}
+
+// ScheduledArticleDto 排程中的文章。
+type ScheduledArticleDto struct {
+	ID          uint       `json:"id"`
+	Title       string     `json:"title"`
+	Slug        string     `json:"slug"`
+	ScheduledAt *time.Time `json:"scheduledAt"`
+	Overdue     bool       `json:"overdue"` // 已到期但尚未被排程轉換
+}
+
+// PublishLogDto 發佈狀態轉換紀錄。
+type PublishLogDto struct {
+	ID          uint       `json:"id"`
+	ArticleID   uint       `json:"articleId"`
+	FromStatus  string     `json:"fromStatus"`
+	ToStatus    string     `json:"toStatus"`
+	Actor       string     `json:"actor"`
+	ScheduledAt *time.Time `json:"scheduledAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
@@ -0,0 +1,75 @@
+package dto
+
+import "time"
+
+// ── 文章系列（/api/series、/api/admin/series）─────────────────────
+
+// SeriesDto 系列摘要。ArticleCount 前台只計已發佈文章，後台計所有成員（不含垃圾桶）。
+type SeriesDto struct {
+	ID           uint      `json:"id"`
+	Title        string    `json:"title"`
+	Slug         string    `json:"slug"`
+	Description  *string   `json:"description"`
+	CoverImage   *string   `json:"coverImage"`
+	ArticleCount int       `json:"articleCount"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// SeriesArticleDto 系列中的一篇文章。Status 只在後台回傳。
+type SeriesArticleDto struct {
+	ID          uint    `json:"id"`
+	Title       string  `json:"title"`
+	Slug        string  `json:"slug"`
+	Summary     *string `json:"summary"`
+	PublishedAt *string `json:"publishedAt"`
+	Position    int     `json:"position"`
+	Note        *string `json:"note"`
+	Status      string  `json:"status,omitempty"`
+}
+
+// SeriesDetailDto 系列與依順序排列的文章。
+type SeriesDetailDto struct {
+	SeriesDto
+	Articles []SeriesArticleDto `json:"articles"`
+}
+
+// SeriesRefDto 文章所屬系列（GET /api/articles/:id/related 用）。
+type SeriesRefDto struct {
+	ID    uint   `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+}
+
+// CreateSeriesRequest POST /api/admin/series（slug 空字串時由 title 產生）
+type CreateSeriesRequest struct {
+	Title       string  `json:"title" binding:"required,max=200"`
+	Slug        string  `json:"slug" binding:"omitempty,max=200"`
+	Description *string `json:"description"`
+	CoverImage  *string `json:"coverImage" binding:"omitempty,max=500"`
+}
+
+// UpdateSeriesRequest PUT /api/admin/series/:id（全欄位替換語義）
+type UpdateSeriesRequest struct {
+	Title       string  `json:"title" binding:"required,max=200"`
+	Slug        string  `json:"slug" binding:"required,max=200"`
+	Description *string `json:"description"`
+	CoverImage  *string `json:"coverImage" binding:"omitempty,max=500"`
+}
+
+// AddSeriesArticleRequest POST /api/admin/series/:id/articles
+//
+//   - position: 插入位置（1 起）；0 或大於目前篇數時接在最後，原本該位置以後的文章往後移
+//   - 已在本系列的文章視為移動位置並更新 note
+type AddSeriesArticleRequest struct {
+	ArticleID uint    `json:"articleId" binding:"required"`
+	Position  int     `json:"position" binding:"min=0"`
+	Note      *string `json:"note" binding:"omitempty,max=200"`
+}
+
+// ReorderSeriesRequest PUT /api/admin/series/:id/articles/order
+//
+// articleIds 須恰好是系列目前所有成員的新順序。
+type ReorderSeriesRequest struct {
+	ArticleIDs []uint `json:"articleIds" binding:"required,min=1"`
+}
@@ -0,0 +1,67 @@
+package dto
+
+import "time"
+
+// ── 垃圾訊息檢查（/api/form-token、/api/admin/spam）──────────────────
+
+// SpamFieldsDto 公開寫入請求可附帶的防機器人欄位。
+type SpamFieldsDto struct {
+	Website   string `json:"website"`   // honeypot：前端隱藏，真人不會填
+	FormToken string `json:"formToken"` // GET /api/form-token 取得
+}
+
+// FormTokenDto 表單 token（載入表單時取得，送出時附上）。
+type FormTokenDto struct {
+	Token            string    `json:"token"`
+	MinSubmitSeconds int       `json:"minSubmitSeconds"` // 取得後至少等待秒數才可送出
+	ExpiresAt        time.Time `json:"expiresAt"`
+}
+
+// SpamDecisionQueryParams 判定紀錄查詢參數。
+type SpamDecisionQueryParams struct {
+	Verdict   string `form:"verdict"`   // spam | allow（空 = 全部）
+	Kind      string `form:"kind"`      // comment | like（空 = 全部）
+	ArticleID uint   `form:"articleId"` // 0 = 不限
+	Page      int    `form:"page"`
+	PageSize  int    `form:"pageSize"`
+}
+
+func (q *SpamDecisionQueryParams) GetPage() int {
+	if q.Page < 1 {
+		return 1
+	}
+	return q.Page
+}
+
+func (q *SpamDecisionQueryParams) GetPageSize() int {
+	if q.PageSize < 1 || q.PageSize > 100 {
+		return 20
+	}
+	return q.PageSize
+}
+
+// SpamDecisionDto 單筆判定紀錄。
+type SpamDecisionDto struct {
+	ID         uint      `json:"id"`
+	Kind       string    `json:"kind"`
+	ArticleID  uint      `json:"articleId"`
+	Verdict    string    `json:"verdict"`
+	Strategy   string    `json:"strategy"`
+	Reason     string    `json:"reason"`
+	IPAddress  string    `json:"ipAddress"`
+	UserAgent  string    `json:"userAgent"`
+	AuthorName string    `json:"authorName"`
+	Excerpt    string    `json:"excerpt"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// SpamSettingsDto 目前生效的垃圾訊息檢查設定（唯讀，來自 Config）。
+type SpamSettingsDto struct {
+	Enabled                bool     `json:"enabled"`
+	Strategies             []string `json:"strategies"` // 依執行順序
+	MinSubmitSeconds       int      `json:"minSubmitSeconds"`
+	MaxLinks               int      `json:"maxLinks"`
+	BlocklistSize          int      `json:"blocklistSize"`
+	DuplicateWindowMinutes int      `json:"duplicateWindowMinutes"`
+	LogAllowed             bool     `json:"logAllowed"`
+}
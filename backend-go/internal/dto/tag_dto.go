@@ -0,0 +1,33 @@
+package dto
+
+// CreateTagRequest POST /api/admin/tags
+//
+//   - name: 必填
+//   - slug: 選填，空字串時自動由 name 產生
+type CreateTagRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+	Slug string `json:"slug" binding:"omitempty,max=100"`
+}
+
+// UpdateTagRequest PUT /api/admin/tags/:id（改名 / 改 slug）
+//
+// 採全欄位替換語義，name、slug 皆必填。
+type UpdateTagRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+	Slug string `json:"slug" binding:"required,max=100"`
+}
+
+// MergeTagsRequest POST /api/admin/tags/merge
+//
+// 把 sourceIds 的所有文章關聯移到 targetId，之後刪除 source 標籤。
+type MergeTagsRequest struct {
+	SourceIDs []uint `json:"sourceIds" binding:"required,min=1"`
+	TargetID  uint   `json:"targetId" binding:"required"`
+}
+
+// MergeTagsResultDto 合併結果。
+type MergeTagsResultDto struct {
+	Target        TagDto `json:"target"`        // 合併後的 target（含文章數）
+	MergedTagIDs  []uint `json:"mergedTagIds"`  // 已刪除的 source 標籤
+	ArticlesMoved int    `json:"articlesMoved"` // 新掛上 target 的文章數（原本已有 target 者不計）
+}
@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+// ── 文章垃圾桶（/api/admin/trash）────────────────────────────────
+
+// TrashQueryParams 垃圾桶列表查詢參數。
+type TrashQueryParams struct {
+	Page     int `form:"page"`
+	PageSize int `form:"pageSize"`
+}
+
+func (q *TrashQueryParams) GetPage() int {
+	if q.Page < 1 {
+		return 1
+	}
+	return q.Page
+}
+
+func (q *TrashQueryParams) GetPageSize() int {
+	if q.PageSize < 1 || q.PageSize > 100 {
+		return 20
+	}
+	return q.PageSize
+}
+
+// TrashedArticleDto 垃圾桶中的文章。
+type TrashedArticleDto struct {
+	ID           uint         `json:"id"`
+	Title        string       `json:"title"`
+	Slug         string       `json:"slug"`
+	Status       string       `json:"status"` // 刪除前的狀態，還原後沿用
+	Category     *CategoryDto `json:"category"`
+	Author       UserDto      `json:"author"`
+	Version      int          `json:"version"`
+	DeletedAt    time.Time    `json:"deletedAt"`
+	DeletedBy    *uint        `json:"deletedBy"`
+	PurgeAfter   time.Time    `json:"purgeAfter"` // 超過此時間會被 purge job 永久刪除
+	ArchiveCount int64        `json:"archiveCount"`
+}
+
+// TrashPurgeResultDto 清空垃圾桶結果。
+type TrashPurgeResultDto struct {
+	Purged []uint `json:"purged"` // 已永久刪除的文章 ID
+}
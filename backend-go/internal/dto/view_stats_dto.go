@@ -0,0 +1,30 @@
+package dto
+
+// ── 瀏覽數統計（/api/admin/articles/:id/stats）───────────────────────
+
+// ViewStatsQueryParams 每日瀏覽數查詢參數。
+type ViewStatsQueryParams struct {
+	Days int `form:"days"` // 最近 N 天（含今天），預設 30，上限 365
+}
+
+func (q *ViewStatsQueryParams) GetDays() int {
+	if q.Days < 1 || q.Days > 365 {
+		return 30
+	}
+	return q.Days
+}
+
+// DailyViewsDto 單日瀏覽數。
+type DailyViewsDto struct {
+	Date  string `json:"date"` // YYYY-MM-DD（UTC）
+	Views int    `json:"views"`
+}
+
+// ArticleViewStatsDto 文章瀏覽數：累計總數與區間內每日數字（無資料的日子補 0）。
+type ArticleViewStatsDto struct {
+	ArticleID  uint            `json:"articleId"`
+	Title      string          `json:"title"`
+	ViewCount  int             `json:"viewCount"`  // 累計（articles.view_count）
+	RangeViews int             `json:"rangeViews"` // 區間合計
+	Days       []DailyViewsDto `json:"days"`       // 舊 → 新
+}
@@ -0,0 +1,147 @@
+// Package fingerprint 為匿名讀者產生不可回推的識別碼，用於按讚等去重。
+//
+// 兩種來源：
+//   - 簽章的匿名 cookie（隨機 reader ID + HMAC）：長期穩定，瀏覽器正常使用時以此為準
+//   - IP + User-Agent 以輪替 salt 雜湊：按讚等寫入一律另以此去重（新 cookie 隨時可取得），
+//     也是沒帶 cookie（腳本、首次造訪）時的識別依據；
+//     salt 由金鑰與時間區段（saltTTL）以 HMAC 推導：所有 instance 共用、重啟不變，
+//     區段結束即換新。只有持有金鑰者能重算舊 salt，金鑰須與 JWT 金鑰同等保密
+//
+// 資料庫只存 HMAC / 雜湊結果，不存 IP、UA 或 cookie 原值。
+package fingerprint
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CookieName 匿名讀者 cookie 名稱。
+const CookieName = "pf_reader"
+
+// CookieMaxAge 匿名讀者 cookie 有效期（秒）。
+const CookieMaxAge = 365 * 24 * 60 * 60
+
+// Reader 一次請求的讀者識別結果。
+type Reader struct {
+	ID          string // 依 cookie 的 reader ID 推導出的指紋（存 DB 用）
+	NetworkHash string // IP + UA 以目前 salt 雜湊（salt 輪替後即失效）
+	NewCookie   string // 非空時代表請求沒有有效 cookie，需以此值回寫 cookie
+	// Established cookie 簽發已超過一個 salt 週期（或為簽發時間欄位加入前的舊 cookie）。
+	// 新 cookie 隨時可以拿到，未 Established 的讀者寫入時仍須以 NetworkHash 去重。
+	Established bool
+}
+
+// Keys 讀者的去重鍵：有 cookie 時為 reader ID；沒有時為 IP+UA 雜湊，加上本次新 cookie 的 reader ID
+// （回應會寫入這個 cookie，下一次請求改以 reader ID 識別，兩個鍵都要記下才不會重複計算）。
+func (r Reader) Keys() []string {
+	if r.Anonymous() {
+		return []string{"n:" + r.NetworkHash, "r:" + r.ID}
+	}
+	return []string{"r:" + r.ID}
+}
+
+// Anonymous 請求未帶有效 cookie（reader ID 為本次新產生）。
+func (r Reader) Anonymous() bool {
+	return r.NewCookie != ""
+}
+
+// Identifier 產生讀者識別碼。
+type Identifier struct {
+	secret  []byte
+	saltTTL time.Duration
+
+	mu         sync.Mutex
+	salt       []byte
+	saltBucket int64
+}
+
+// New 建立 Identifier；saltTTL 為 IP+UA 雜湊 salt 的輪替週期。
+func New(secret string, saltTTL time.Duration) *Identifier {
+	return &Identifier{secret: []byte(secret), saltTTL: saltTTL}
+}
+
+// Identify 依 cookie 值、IP 與 User-Agent 識別讀者。cookie 不存在或簽章不符時產生新的 reader ID。
+//
+// cookie 格式為 "readerID.簽發時間.簽章"；舊格式 "readerID.簽章" 仍接受，視為 Established。
+func (i *Identifier) Identify(cookie, ip, userAgent string) Reader {
+	var r Reader
+	now := time.Now()
+	readerID, issued, ok := i.verifyCookie(cookie)
+	if !ok {
+		readerID = randomID()
+		payload := readerID + "." + strconv.FormatInt(now.Unix(), 10)
+		r.NewCookie = payload + "." + i.sign("cookie:"+payload)
+	} else {
+		r.Established = issued.IsZero() || now.Sub(issued) >= i.saltTTL
+	}
+	r.ID = i.sign("reader:" + readerID)
+	r.NetworkHash = i.NetworkHash(ip, userAgent)
+	return r
+}
+
+// NetworkHash IP + User-Agent 以目前 salt 雜湊，不需 cookie（salt 輪替後同一讀者會得到新的值）。
+func (i *Identifier) NetworkHash(ip, userAgent string) string {
+	mac := hmac.New(sha256.New, i.currentSalt())
+	mac.Write([]byte(ip + "\x00" + userAgent))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyCookie 驗證 cookie 簽章，回傳 reader ID 與簽發時間（舊格式為零值）。
+func (i *Identifier) verifyCookie(cookie string) (string, time.Time, bool) {
+	idx := strings.LastIndex(cookie, ".")
+	if idx <= 0 {
+		return "", time.Time{}, false
+	}
+	payload, sig := cookie[:idx], cookie[idx+1:]
+	if !hmac.Equal([]byte(sig), []byte(i.sign("cookie:"+payload))) {
+		return "", time.Time{}, false
+	}
+	id, ts, hasTime := strings.Cut(payload, ".")
+	if !hasTime {
+		return id, time.Time{}, true
+	}
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || id == "" {
+		return "", time.Time{}, false
+	}
+	return id, time.Unix(sec, 0), true
+}
+
+// currentSalt 目前時間區段的 salt：HMAC(secret, floor(now / saltTTL))。
+// 不用隨機值，多個 instance 與重啟後才會算出相同的 NetworkHash，去重才有效。
+func (i *Identifier) currentSalt() []byte {
+	ttl := int64(i.saltTTL / time.Second)
+	if ttl <= 0 {
+		ttl = int64(24 * time.Hour / time.Second)
+	}
+	bucket := time.Now().Unix() / ttl
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.salt == nil || i.saltBucket != bucket {
+		mac := hmac.New(sha256.New, i.secret)
+		mac.Write([]byte("salt:" + strconv.FormatInt(bucket, 10)))
+		i.salt = mac.Sum(nil)
+		i.saltBucket = bucket
+	}
+	return i.salt
+}
+
+func (i *Identifier) sign(s string) string {
+	mac := hmac.New(sha256.New, i.secret)
+	mac.Write([]byte(s))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func randomID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
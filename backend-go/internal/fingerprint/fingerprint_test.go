@@ -0,0 +1,52 @@
+package fingerprint
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// 同一金鑰的兩個 Identifier（不同 instance / 重啟後）對同一 IP+UA 算出相同的雜湊。
+func TestNetworkHashSharedAcrossInstances(t *testing.T) {
+	a := New("secret", time.Hour)
+	b := New("secret", time.Hour)
+	if a.NetworkHash("203.0.113.7", "curl/8.0") != b.NetworkHash("203.0.113.7", "curl/8.0") {
+		t.Error("same secret produced different network hashes")
+	}
+	if a.NetworkHash("203.0.113.7", "curl/8.0") == a.NetworkHash("198.51.100.9", "curl/8.0") {
+		t.Error("different IPs produced the same network hash")
+	}
+	if a.NetworkHash("203.0.113.7", "curl/8.0") == New("other", time.Hour).NetworkHash("203.0.113.7", "curl/8.0") {
+		t.Error("different secrets produced the same network hash")
+	}
+}
+
+// 新簽發的 cookie 未滿 salt 週期不算 Established；舊格式與超過週期的 cookie 算。
+func TestIdentifyEstablished(t *testing.T) {
+	i := New("secret", time.Hour)
+	const ip, ua = "203.0.113.7", "Mozilla/5.0"
+
+	first := i.Identify("", ip, ua)
+	if !first.Anonymous() || first.Established {
+		t.Fatalf("fresh reader: anonymous=%v established=%v", first.Anonymous(), first.Established)
+	}
+	again := i.Identify(first.NewCookie, ip, ua)
+	if again.Anonymous() || again.Established || again.ID != first.ID {
+		t.Errorf("new cookie: anonymous=%v established=%v sameID=%v", again.Anonymous(), again.Established, again.ID == first.ID)
+	}
+
+	legacy := i.Identify("abc."+i.sign("cookie:abc"), ip, ua)
+	if legacy.Anonymous() || !legacy.Established {
+		t.Errorf("legacy cookie: anonymous=%v established=%v", legacy.Anonymous(), legacy.Established)
+	}
+
+	payload := "abc." + strconv.FormatInt(time.Now().Add(-2*time.Hour).Unix(), 10)
+	old := i.Identify(payload+"."+i.sign("cookie:"+payload), ip, ua)
+	if old.Anonymous() || !old.Established || old.ID != legacy.ID {
+		t.Errorf("old cookie: anonymous=%v established=%v sameID=%v", old.Anonymous(), old.Established, old.ID == legacy.ID)
+	}
+
+	if forged := i.Identify("abc."+strconv.FormatInt(time.Now().Add(-2*time.Hour).Unix(), 10)+"."+i.sign("cookie:abc"), ip, ua); !forged.Anonymous() {
+		t.Error("cookie with tampered issue time accepted")
+	}
+}
@@ -0,0 +1,326 @@
+// Package graph 把文章知識串連輸出成 JSON Graph Format、GraphML 與 Graphviz DOT，並計算基本圖統計。
+//
+// 只負責格式轉換與統計；要納入哪些文章與串連由 services 決定。
+// 邊一律為有向：related 依建立時的 from → to，series 為系列中前一篇 → 下一篇。
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Node 一篇文章。
+type Node struct {
+	ID       uint
+	Title    string
+	Slug     string
+	Status   string
+	Category string // 分類名稱，無分類為空字串
+	Tags     []string
+}
+
+// Edge 一條串連。Series 為系列標題（relation = series 時）。
+type Edge struct {
+	From     uint
+	To       uint
+	Relation string
+	Note     string
+	Series   string
+}
+
+// Graph 待輸出的圖。Nodes 與 Edges 的順序即輸出順序。
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+}
+
+// Component 一個連通分量（不看方向）。
+type Component struct {
+	Size    int
+	NodeIDs []uint // 由小到大
+}
+
+// Stats 基本圖統計。
+type Stats struct {
+	NodeCount      int
+	EdgeCount      int
+	EdgesByType    map[string]int
+	Orphans        []uint // 沒有任何串連的文章
+	ComponentCount int    // 連通分量數（含孤立點）
+	Largest        []Component
+}
+
+// ComputeStats 計算統計；topN 為回傳的最大連通分量數（不含孤立點）。
+func (g *Graph) ComputeStats(topN int) Stats {
+	st := Stats{NodeCount: len(g.Nodes), EdgeCount: len(g.Edges), EdgesByType: map[string]int{}}
+
+	parent := make(map[uint]uint, len(g.Nodes))
+	var find func(uint) uint
+	find = func(x uint) uint {
+		for parent[x] != x {
+			parent[x] = parent[parent[x]]
+			x = parent[x]
+		}
+		return x
+	}
+	for _, n := range g.Nodes {
+		parent[n.ID] = n.ID
+	}
+	degree := make(map[uint]int, len(g.Nodes))
+	for _, e := range g.Edges {
+		st.EdgesByType[e.Relation]++
+		degree[e.From]++
+		degree[e.To]++
+		if _, ok := parent[e.From]; !ok {
+			continue
+		}
+		if _, ok := parent[e.To]; !ok {
+			continue
+		}
+		if a, b := find(e.From), find(e.To); a != b {
+			parent[a] = b
+		}
+	}
+
+	groups := map[uint][]uint{}
+	for _, n := range g.Nodes {
+		if degree[n.ID] == 0 {
+			st.Orphans = append(st.Orphans, n.ID)
+		}
+		root := find(n.ID)
+		groups[root] = append(groups[root], n.ID)
+	}
+	st.ComponentCount = len(groups)
+	sort.Slice(st.Orphans, func(i, j int) bool { return st.Orphans[i] < st.Orphans[j] })
+
+	for _, ids := range groups {
+		if len(ids) < 2 {
+			continue
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		st.Largest = append(st.Largest, Component{Size: len(ids), NodeIDs: ids})
+	}
+	sort.Slice(st.Largest, func(i, j int) bool {
+		if st.Largest[i].Size != st.Largest[j].Size {
+			return st.Largest[i].Size > st.Largest[j].Size
+		}
+		return st.Largest[i].NodeIDs[0] < st.Largest[j].NodeIDs[0]
+	})
+	if len(st.Largest) > topN {
+		st.Largest = st.Largest[:topN]
+	}
+	return st
+}
+
+// ── JSON Graph Format（https://jsongraphformat.info, v2）──────────────
+
+type jgfDoc struct {
+	Graph jgfGraph `json:"graph"`
+}
+
+type jgfGraph struct {
+	Directed bool               `json:"directed"`
+	Type     string             `json:"type"`
+	Label    string             `json:"label"`
+	Nodes    map[string]jgfNode `json:"nodes"`
+	Edges    []jgfEdge          `json:"edges"`
+}
+
+type jgfNode struct {
+	Label    string          `json:"label"`
+	Metadata jgfNodeMetadata `json:"metadata"`
+}
+
+type jgfNodeMetadata struct {
+	Slug     string   `json:"slug"`
+	Status   string   `json:"status"`
+	Category string   `json:"category,omitempty"`
+	Tags     []string `json:"tags"`
+}
+
+type jgfEdge struct {
+	Source   string          `json:"source"`
+	Target   string          `json:"target"`
+	Relation string          `json:"relation"`
+	Metadata jgfEdgeMetadata `json:"metadata"`
+}
+
+type jgfEdgeMetadata struct {
+	Note   string `json:"note,omitempty"`
+	Series string `json:"series,omitempty"`
+}
+
+// JSON 輸出 JSON Graph Format。
+func JSON(g *Graph) ([]byte, error) {
+	doc := jgfDoc{Graph: jgfGraph{
+		Directed: true,
+		Type:     "article-links",
+		Label:    "Article knowledge graph",
+		Nodes:    make(map[string]jgfNode, len(g.Nodes)),
+		Edges:    make([]jgfEdge, len(g.Edges)),
+	}}
+	for _, n := range g.Nodes {
+		tags := n.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		doc.Graph.Nodes[nodeKey(n.ID)] = jgfNode{
+			Label:    n.Title,
+			Metadata: jgfNodeMetadata{Slug: n.Slug, Status: n.Status, Category: n.Category, Tags: tags},
+		}
+	}
+	for i, e := range g.Edges {
+		doc.Graph.Edges[i] = jgfEdge{
+			Source:   nodeKey(e.From),
+			Target:   nodeKey(e.To),
+			Relation: e.Relation,
+			Metadata: jgfEdgeMetadata{Note: e.Note, Series: e.Series},
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ── GraphML（http://graphml.graphdrawing.org/）─────────────────────
+
+type gmlDoc struct {
+	XMLName xml.Name `xml:"graphml"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Keys    []gmlKey `xml:"key"`
+	Graph   gmlGraph `xml:"graph"`
+}
+
+type gmlKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type gmlGraph struct {
+	ID          string    `xml:"id,attr"`
+	EdgeDefault string    `xml:"edgedefault,attr"`
+	Nodes       []gmlNode `xml:"node"`
+	Edges       []gmlEdge `xml:"edge"`
+}
+
+type gmlNode struct {
+	ID   string    `xml:"id,attr"`
+	Data []gmlData `xml:"data"`
+}
+
+type gmlEdge struct {
+	Source string    `xml:"source,attr"`
+	Target string    `xml:"target,attr"`
+	Data   []gmlData `xml:"data"`
+}
+
+type gmlData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// GraphML 輸出 GraphML（tags 以逗號串接成單一屬性）。
+func GraphML(g *Graph) ([]byte, error) {
+	doc := gmlDoc{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []gmlKey{
+			{ID: "title", For: "node", AttrName: "title", AttrType: "string"},
+			{ID: "slug", For: "node", AttrName: "slug", AttrType: "string"},
+			{ID: "status", For: "node", AttrName: "status", AttrType: "string"},
+			{ID: "category", For: "node", AttrName: "category", AttrType: "string"},
+			{ID: "tags", For: "node", AttrName: "tags", AttrType: "string"},
+			{ID: "relation", For: "edge", AttrName: "relation", AttrType: "string"},
+			{ID: "note", For: "edge", AttrName: "note", AttrType: "string"},
+			{ID: "series", For: "edge", AttrName: "series", AttrType: "string"},
+		},
+		Graph: gmlGraph{ID: "articles", EdgeDefault: "directed"},
+	}
+	for _, n := range g.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, gmlNode{ID: nodeKey(n.ID), Data: compactData(
+			gmlData{Key: "title", Value: n.Title},
+			gmlData{Key: "slug", Value: n.Slug},
+			gmlData{Key: "status", Value: n.Status},
+			gmlData{Key: "category", Value: n.Category},
+			gmlData{Key: "tags", Value: strings.Join(n.Tags, ",")},
+		)})
+	}
+	for _, e := range g.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, gmlEdge{Source: nodeKey(e.From), Target: nodeKey(e.To), Data: compactData(
+			gmlData{Key: "relation", Value: e.Relation},
+			gmlData{Key: "note", Value: e.Note},
+			gmlData{Key: "series", Value: e.Series},
+		)})
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
+
+// compactData 略過空值（GraphML 中未出現的 data 即為缺值）。
+func compactData(data ...gmlData) []gmlData {
+	out := data[:0]
+	for _, d := range data {
+		if d.Value != "" {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// ── Graphviz DOT ─────────────────────────────────────────────────
+
+// DOT 輸出 Graphviz digraph；series 實線、related 虛線，未發佈文章以灰色標示。
+func DOT(g *Graph) []byte {
+	var b strings.Builder
+	b.WriteString("digraph articles {\n")
+	b.WriteString("  node [shape=box, style=rounded];\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "  %s [label=%s", nodeKey(n.ID), dotQuote(n.Title))
+		if n.Status != "published" {
+			b.WriteString(", color=gray, fontcolor=gray")
+		}
+		if n.Category != "" {
+			fmt.Fprintf(&b, ", tooltip=%s", dotQuote(n.Category))
+		}
+		b.WriteString("];\n")
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  %s -> %s [label=%s", nodeKey(e.From), nodeKey(e.To), dotQuote(e.Relation))
+		if e.Relation != "series" {
+			b.WriteString(", style=dashed")
+		}
+		if e.Note != "" {
+			fmt.Fprintf(&b, ", tooltip=%s", dotQuote(e.Note))
+		}
+		b.WriteString("];\n")
+	}
+	b.WriteString("}\n")
+	return []byte(b.String())
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+func nodeKey(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
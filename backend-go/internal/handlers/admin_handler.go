@@ -29,7 +29,7 @@ func (h *AdminHandler) ListArticles(c *gin.Context) {
 
 	resp, err := h.articleSvc.GetArticles(q, true)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.Fail[any]("查詢失敗"))
+		handleErr(c, err, "查詢失敗")
 		return
 	}
 
@@ -50,6 +50,7 @@ func (h *AdminHandler) GetArticle(c *gin.Context) {
 		return
 	}
 
+	setVersionETag(c, article.Version)
 	c.JSON(http.StatusOK, dto.Ok(article, ""))
 }
 
@@ -95,6 +96,10 @@ func (h *AdminHandler) UpdateArticle(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, dto.Fail[any]("請求格式錯誤: "+err.Error()))
 		return
 	}
+	if req.ExpectedVersion, err = expectedVersionFromRequest(c, req.ExpectedVersion); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("If-Match 格式錯誤"))
+		return
+	}
 
 	article, err := h.articleSvc.UpdateArticle(id, req, userID)
 	if err != nil {
@@ -102,6 +107,7 @@ func (h *AdminHandler) UpdateArticle(c *gin.Context) {
 		return
 	}
 
+	setVersionETag(c, article.Version)
 	c.JSON(http.StatusOK, dto.Ok(article, "文章更新成功"))
 }
 
@@ -137,9 +143,14 @@ func (h *AdminHandler) PatchArticle(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, dto.Fail[any]("請求格式錯誤: "+err.Error()))
 		return
 	}
+	if req.ExpectedVersion, err = expectedVersionFromRequest(c, req.ExpectedVersion); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("If-Match 格式錯誤"))
+		return
+	}
 
 	// 建立 fields mask：只有 JSON 中有出現的 key 才算「有傳送」
 	_, hasTitle := rawFields["title"]
+	_, hasSlug := rawFields["slug"]
 	_, hasSummary := rawFields["summary"]
 	_, hasContent := rawFields["content"]
 	_, hasCoverImage := rawFields["coverImage"]
@@ -148,6 +159,7 @@ func (h *AdminHandler) PatchArticle(c *gin.Context) {
 
 	fields := dto.PatchArticleFields{
 		HasTitle:      hasTitle,
+		HasSlug:       hasSlug,
 		HasSummary:    hasSummary,
 		HasContent:    hasContent,
 		HasCoverImage: hasCoverImage,
@@ -161,6 +173,7 @@ func (h *AdminHandler) PatchArticle(c *gin.Context) {
 		return
 	}
 
+	setVersionETag(c, article.Version)
 	c.JSON(http.StatusOK, dto.Ok(article, "文章更新成功"))
 }
 
@@ -181,6 +194,30 @@ func (h *AdminHandler) GetArticleArchives(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.Ok(archives, ""))
 }
 
+// GET /api/admin/articles/:id/archives/diff?from=&to= — 比對兩個版本
+// from / to 為 archive ID 或 "current"；to 省略時預設 current。
+func (h *AdminHandler) DiffArticleArchives(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("ID 格式錯誤"))
+		return
+	}
+	from := c.Query("from")
+	if from == "" {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("缺少 from 參數"))
+		return
+	}
+	to := c.DefaultQuery("to", services.DiffRefCurrent)
+
+	diff, err := h.articleSvc.DiffArticleVersions(id, from, to)
+	if err != nil {
+		handleErr(c, err, "版本比對失敗")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.Ok(diff, ""))
+}
+
 // GET /api/admin/articles/:id/archives/:archiveId — 取得歷史版本完整內容
 func (h *AdminHandler) GetArticleArchiveDetail(c *gin.Context) {
 	archiveID, err := parseUintParam(c, "archiveId")
@@ -227,7 +264,7 @@ func (h *AdminHandler) RestoreArticle(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.Ok(article, "文章已還原"))
 }
 
-// DELETE /api/admin/articles/:id
+// DELETE /api/admin/articles/:id — 移到垃圾桶（可從 /api/admin/trash 還原）
 func (h *AdminHandler) DeleteArticle(c *gin.Context) {
 	userID, ok := getUserIDFromContext(c)
 	if !ok {
@@ -246,7 +283,7 @@ func (h *AdminHandler) DeleteArticle(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.Ok(true, "文章刪除成功"))
+	c.JSON(http.StatusOK, dto.Ok(true, "文章已移到垃圾桶"))
 }
 
 // POST /api/admin/articles/:id/publish
@@ -306,10 +343,85 @@ func (h *AdminHandler) SearchArticles(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, dto.Fail[any]("查詢參數錯誤"))
 		return
 	}
-	resp, err := h.articleSvc.SearchArticles(p)
+	resp, err := h.articleSvc.SearchArticles(p, true)
 	if err != nil {
 		handleErr(c, err, "檢索失敗")
 		return
 	}
 	c.JSON(http.StatusOK, dto.Ok(resp, ""))
 }
+
+// GET /api/admin/articles/:id/stats?days=30 — 每日瀏覽數（UTC 日期，無資料補 0）
+func (h *AdminHandler) GetArticleViewStats(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("無效的文章 ID"))
+		return
+	}
+	var q dto.ViewStatsQueryParams
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("查詢參數錯誤"))
+		return
+	}
+
+	stats, err := h.articleSvc.GetViewStats(id, q)
+	if err != nil {
+		handleErr(c, err, "查詢失敗")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.Ok(stats, ""))
+}
+
+// GET /api/admin/graph?format=json|graphml|dot&relation=&status=&category=&tags=&hideOrphans=
+// 匯出文章知識圖譜（以附件下載）
+func (h *AdminHandler) ExportGraph(c *gin.Context) {
+	var q dto.GraphQueryParams
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("查詢參數錯誤"))
+		return
+	}
+
+	export, err := h.articleSvc.ExportGraph(q)
+	if err != nil {
+		handleErr(c, err, "匯出失敗")
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+export.Filename+`"`)
+	c.Data(http.StatusOK, export.ContentType, export.Body)
+}
+
+// GET /api/admin/graph/stats — 孤立文章、連通分量（篩選參數同匯出）
+func (h *AdminHandler) GraphStats(c *gin.Context) {
+	var q dto.GraphQueryParams
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("查詢參數錯誤"))
+		return
+	}
+
+	stats, err := h.articleSvc.GetGraphStats(q)
+	if err != nil {
+		handleErr(c, err, "查詢失敗")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.Ok(stats, ""))
+}
+
+// GET /api/admin/reactions/stats?type=&days=&limit= — 各表情總數與文章排行
+func (h *AdminHandler) ReactionStats(c *gin.Context) {
+	var q dto.ReactionStatsQueryParams
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("查詢參數錯誤"))
+		return
+	}
+
+	stats, err := h.articleSvc.GetReactionStats(q)
+	if err != nil {
+		handleErr(c, err, "查詢失敗")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.Ok(stats, ""))
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/fingerprint"
+	"github.com/paulhuang/paulfun-blogger/internal/services"
+)
+
+// beaconMaxBytes beacon body 上限（正常只有幾百 bytes）。
+const beaconMaxBytes = 4 << 10
+
+// AnalyticsHandler 讀者分析 beacon（公開）與後台報表（admin）。
+type AnalyticsHandler struct {
+	svc     *services.AnalyticsService
+	readers *fingerprint.Identifier
+}
+
+func NewAnalyticsHandler(svc *services.AnalyticsService, readers *fingerprint.Identifier) *AnalyticsHandler {
+	return &AnalyticsHandler{svc: svc, readers: readers}
+}
+
+// POST /api/analytics/beacon — 前台以 navigator.sendBeacon 送出，一律回 204。
+// 不發 cookie；瀏覽器帶 DNT: 1 或 Sec-GPC: 1 時不記錄。
+func (h *AnalyticsHandler) Beacon(c *gin.Context) {
+	if !h.svc.Enabled() || c.GetHeader("DNT") == "1" || c.GetHeader("Sec-GPC") == "1" {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	// sendBeacon 送字串時 Content-Type 為 text/plain，因此不看 Content-Type 直接解析 JSON
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, beaconMaxBytes)
+	var req dto.BeaconRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("請求格式錯誤"))
+		return
+	}
+
+	ua := c.Request.UserAgent()
+	h.svc.Collect(services.PageViewEvent{
+		ArticleID:   req.ArticleID,
+		Referrer:    req.Referrer,
+		UTMSource:   req.UTMSource,
+		UTMMedium:   req.UTMMedium,
+		UTMCampaign: req.UTMCampaign,
+		UserAgent:   ua,
+		Visitor:     h.readers.NetworkHash(c.ClientIP(), ua),
+	})
+	c.Status(http.StatusNoContent)
+}
+
+// GET /api/admin/analytics/top-articles?from=&to=&limit=
+func (h *AnalyticsHandler) TopArticles(c *gin.Context) {
+	var q dto.TopArticlesQueryParams
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("查詢參數錯誤"))
+		return
+	}
+
+	result, err := h.svc.TopArticles(q)
+	if err != nil {
+		handleErr(c, err, "查詢失敗")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.Ok(result, ""))
+}
+
+// GET /api/admin/analytics/top-sources?by=referrer|source|medium|campaign|device&articleId=&from=&to=&limit=
+func (h *AnalyticsHandler) TopSources(c *gin.Context) {
+	var q dto.TopSourcesQueryParams
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("查詢參數錯誤"))
+		return
+	}
+
+	result, err := h.svc.TopSources(q)
+	if err != nil {
+		handleErr(c, err, "查詢失敗")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.Ok(result, ""))
+}
+
+// GET /api/admin/analytics/articles/:id?from=&to= — 每日瀏覽 / 讀者數與來源摘要
+func (h *AnalyticsHandler) ArticleSeries(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("無效的文章 ID"))
+		return
+	}
+	var q dto.AnalyticsRangeParams
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("查詢參數錯誤"))
+		return
+	}
+
+	result, err := h.svc.ArticleSeries(id, q)
+	if err != nil {
+		handleErr(c, err, "查詢失敗")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.Ok(result, ""))
+}
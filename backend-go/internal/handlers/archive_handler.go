@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/services"
+)
+
+// ArchiveHandler 文章歷史版本保留策略（admin）。
+type ArchiveHandler struct {
+	retentionSvc *services.ArchiveRetentionService
+}
+
+func NewArchiveHandler(retentionSvc *services.ArchiveRetentionService) *ArchiveHandler {
+	return &ArchiveHandler{retentionSvc: retentionSvc}
+}
+
+// POST /api/admin/archives/prune — 套用保留策略（預設 dryRun，只回報將刪除 / 壓縮的版本）
+func (h *ArchiveHandler) Prune(c *gin.Context) {
+	var req dto.ArchivePruneRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.Fail[any]("請求格式錯誤"))
+			return
+		}
+	}
+
+	policy := h.retentionSvc.Policy()
+	if req.KeepLast != nil {
+		policy.KeepLast = *req.KeepLast
+	}
+	if req.DailyAfterDays != nil {
+		policy.DailyAfterDays = *req.DailyAfterDays
+	}
+	if req.CompressAfterDays != nil {
+		policy.CompressAfterDays = *req.CompressAfterDays
+	}
+	if policy.KeepLast < 0 || policy.DailyAfterDays < 0 || policy.CompressAfterDays < 0 {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("保留策略參數不可為負數"))
+		return
+	}
+	dryRun := req.DryRun == nil || *req.DryRun
+
+	report, err := h.retentionSvc.Apply(policy, req.ArticleID, dryRun)
+	if err != nil {
+		handleErr(c, err, "套用保留策略失敗")
+		return
+	}
+
+	msg := "保留策略已套用"
+	if dryRun {
+		msg = "預覽（dryRun），未實際變更"
+	}
+	c.JSON(http.StatusOK, dto.Ok(report, msg))
+}
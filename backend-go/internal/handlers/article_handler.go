@@ -2,19 +2,25 @@ package handlers
 
 import (
 	"net/http"
+	"net/url"
 
 	"github.com/gin-gonic/gin"
 	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/fingerprint"
 	"github.com/paulhuang/paulfun-blogger/internal/services"
+	"github.com/paulhuang/paulfun-blogger/internal/spam"
 )
 
 // ArticleHandler 處理前台公開文章 API。
 type ArticleHandler struct {
-	svc *services.ArticleService
+	svc     *services.ArticleService
+	spamSvc *services.SpamService
+	readers *fingerprint.Identifier
+	views   *services.ViewTracker
 }
 
-func NewArticleHandler(svc *services.ArticleService) *ArticleHandler {
-	return &ArticleHandler{svc: svc}
+func NewArticleHandler(svc *services.ArticleService, spamSvc *services.SpamService, readers *fingerprint.Identifier, views *services.ViewTracker) *ArticleHandler {
+	return &ArticleHandler{svc: svc, spamSvc: spamSvc, readers: readers, views: views}
 }
 
 // GET /api/articles
@@ -27,13 +33,29 @@ func (h *ArticleHandler) ListArticles(c *gin.Context) {
 
 	resp, err := h.svc.GetArticles(q, false)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.Fail[any]("查詢失敗"))
+		handleErr(c, err, "查詢失敗")
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.Ok(resp, ""))
 }
 
+// GET /api/articles/search — 公開全文檢索（僅已發佈；snippet / matchedFields 同後台）。
+// 參數同 /api/admin/articles/search，status 參數會被忽略；rate limit 於 router 層。
+func (h *ArticleHandler) SearchArticles(c *gin.Context) {
+	var p dto.ArticleSearchParams
+	if err := c.ShouldBindQuery(&p); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("查詢參數錯誤"))
+		return
+	}
+	resp, err := h.svc.SearchArticles(p, false)
+	if err != nil {
+		handleErr(c, err, "檢索失敗")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok(resp, ""))
+}
+
 // GET /api/articles/categories
 func (h *ArticleHandler) ListCategories(c *gin.Context) {
 	cats, err := h.svc.GetCategories()
@@ -44,6 +66,16 @@ func (h *ArticleHandler) ListCategories(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.Ok(cats, ""))
 }
 
+// GET /api/articles/categories/tree — 巢狀分類樹（含子孫分類累計文章數）
+func (h *ArticleHandler) GetCategoryTree(c *gin.Context) {
+	tree, err := h.svc.GetCategoryTree()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.Fail[any]("查詢失敗"))
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok(tree, ""))
+}
+
 // GET /api/articles/tags
 func (h *ArticleHandler) ListTags(c *gin.Context) {
 	tags, err := h.svc.GetTags()
@@ -68,38 +100,184 @@ func (h *ArticleHandler) GetArticleByID(c *gin.Context) {
 		return
 	}
 
-	// 非同步增加瀏覽數
-	go h.svc.IncrementViewCount(article.ID)
+	h.recordView(c, article.ID)
+
+	c.JSON(http.StatusOK, dto.Ok(article, ""))
+}
+
+// GET /api/articles/by-slug/:slug
+// 舊 slug 回 301 + Location 指向目前 slug，body 附 {id, slug} 供前端直接改寫網址。
+func (h *ArticleHandler) GetArticleBySlug(c *gin.Context) {
+	article, redirect, err := h.svc.GetPublishedArticleBySlug(c.Param("slug"))
+	if err != nil {
+		handleErr(c, err, "文章不存在")
+		return
+	}
+	if redirect != nil {
+		c.Header("Location", "/api/articles/by-slug/"+url.PathEscape(redirect.Slug))
+		c.JSON(http.StatusMovedPermanently, dto.Ok(redirect, "文章網址已變更"))
+		return
+	}
+
+	h.recordView(c, article.ID)
 
 	c.JSON(http.StatusOK, dto.Ok(article, ""))
 }
 
-// POST /api/articles/:id/like（公開，匿名按讚；rate limit 於 router 層）
+// recordView 登記一次瀏覽（非阻塞）。帶合法 Bearer token 的請求為作者 / 管理者預覽，不計入；
+// 爬蟲過濾與去重由 ViewTracker 處理。
+func (h *ArticleHandler) recordView(c *gin.Context, articleID uint) {
+	if _, loggedIn := c.Get("userID"); loggedIn {
+		return
+	}
+	ua := c.Request.UserAgent()
+	if services.IsBotUserAgent(ua) {
+		return // 不替爬蟲發 reader cookie
+	}
+	h.views.Record(services.ViewEvent{
+		ArticleID: articleID,
+		Visitors:  identifyReader(c, h.readers).Keys(),
+		UserAgent: ua,
+	})
+}
+
+// GET /api/articles/:id/like（公開，讚數與目前讀者是否已按讚）
+func (h *ArticleHandler) GetLikeStatus(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("無效的文章 ID"))
+		return
+	}
+	status, err := h.svc.LikeStatus(id, identifyReader(c, h.readers))
+	if err != nil {
+		handleErr(c, err, "查詢失敗")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok(status, ""))
+}
+
+// POST /api/articles/:id/like（公開，匿名按讚；冪等，rate limit 於 router 層）
+// body 可選帶 SpamFieldsDto；判定為 spam 時不計數，照常回傳目前狀態。
 func (h *ArticleHandler) LikeArticle(c *gin.Context) {
 	id, err := parseUintParam(c, "id")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, dto.Fail[any]("無效的文章 ID"))
 		return
 	}
-	count, err := h.svc.LikeArticle(id)
+
+	// 先確認文章可按讚，再跑 spam 檢查（避免對任意 ID 的請求灌進判定紀錄）
+	if err := h.svc.EnsureLikeable(id); err != nil {
+		handleErr(c, err, "按讚失敗")
+		return
+	}
+
+	var fields dto.SpamFieldsDto
+	if c.Request.ContentLength > 0 {
+		_ = c.ShouldBindJSON(&fields) // 欄位皆選填，格式錯誤視同未帶
+	}
+	decision := h.spamSvc.Check(spam.Submission{
+		Kind:      spam.KindLike,
+		ArticleID: id,
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		Honeypot:  fields.Website,
+		FormToken: fields.FormToken,
+	})
+
+	reader := identifyReader(c, h.readers)
+	like := h.svc.LikeArticle
+	if decision.Spam {
+		like = h.svc.LikeStatus
+	}
+	status, err := like(id, reader)
 	if err != nil {
 		handleErr(c, err, "按讚失敗")
 		return
 	}
-	c.JSON(http.StatusOK, dto.Ok(gin.H{"likeCount": count}, ""))
+	c.JSON(http.StatusOK, dto.Ok(status, ""))
 }
 
-// POST /api/articles/:id/unlike（公開，收回讚）
+// POST /api/articles/:id/unlike（公開，收回讚；冪等）
 func (h *ArticleHandler) UnlikeArticle(c *gin.Context) {
 	id, err := parseUintParam(c, "id")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, dto.Fail[any]("無效的文章 ID"))
 		return
 	}
-	count, err := h.svc.UnlikeArticle(id)
+	status, err := h.svc.UnlikeArticle(id, identifyReader(c, h.readers))
 	if err != nil {
 		handleErr(c, err, "收回讚失敗")
 		return
 	}
-	c.JSON(http.StatusOK, dto.Ok(gin.H{"likeCount": count}, ""))
+	c.JSON(http.StatusOK, dto.Ok(status, ""))
+}
+
+// GET /api/articles/:id/reactions（公開，各表情回應數與目前讀者已給的回應）
+func (h *ArticleHandler) GetReactions(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("無效的文章 ID"))
+		return
+	}
+	status, err := h.svc.ReactionStatus(id, identifyReader(c, h.readers))
+	if err != nil {
+		handleErr(c, err, "查詢失敗")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok(status, ""))
+}
+
+// POST /api/articles/:id/reactions/:type（公開，冪等；去重與 spam 檢查同按讚）
+func (h *ArticleHandler) AddReaction(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("無效的文章 ID"))
+		return
+	}
+
+	if err := h.svc.EnsureLikeable(id); err != nil {
+		handleErr(c, err, "回應失敗")
+		return
+	}
+
+	var fields dto.SpamFieldsDto
+	if c.Request.ContentLength > 0 {
+		_ = c.ShouldBindJSON(&fields)
+	}
+	decision := h.spamSvc.Check(spam.Submission{
+		Kind:      spam.KindReaction,
+		ArticleID: id,
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		Honeypot:  fields.Website,
+		FormToken: fields.FormToken,
+	})
+
+	reader := identifyReader(c, h.readers)
+	var status dto.ReactionStatusDto
+	if decision.Spam {
+		status, err = h.svc.ReactionStatus(id, reader)
+	} else {
+		status, err = h.svc.AddReaction(id, c.Param("type"), reader)
+	}
+	if err != nil {
+		handleErr(c, err, "回應失敗")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok(status, ""))
+}
+
+// DELETE /api/articles/:id/reactions/:type（公開，收回回應；冪等）
+func (h *ArticleHandler) RemoveReaction(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("無效的文章 ID"))
+		return
+	}
+	status, err := h.svc.RemoveReaction(id, c.Param("type"), identifyReader(c, h.readers))
+	if err != nil {
+		handleErr(c, err, "收回回應失敗")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok(status, ""))
 }
@@ -85,3 +85,45 @@ func (h *ArticleLinkHandler) GetRelated(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, dto.Ok(related, ""))
 }
+
+// GET /api/admin/articles/:id/suggestions — 內容相似度推薦（排除已串連的文章）
+func (h *ArticleLinkHandler) GetSuggestions(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("ID 格式錯誤"))
+		return
+	}
+	suggestions, err := h.linkSvc.GetSuggestions(id)
+	if err != nil {
+		handleErr(c, err, "查詢推薦失敗")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok(suggestions, ""))
+}
+
+// POST /api/admin/articles/:id/suggestions/:targetId/promote — 推薦轉為 related 串連
+func (h *ArticleLinkHandler) PromoteSuggestion(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("ID 格式錯誤"))
+		return
+	}
+	targetID, err := parseUintParam(c, "targetId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("targetId 格式錯誤"))
+		return
+	}
+	var req dto.PromoteSuggestionRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.Fail[any]("請求格式錯誤"))
+			return
+		}
+	}
+	link, err := h.linkSvc.PromoteSuggestion(id, targetID, req.Note)
+	if err != nil {
+		handleErr(c, err, "建立串連失敗")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.Ok(link, "串連建立成功"))
+}
@@ -52,6 +52,21 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.Ok(cat, "分類更新成功"))
 }
 
+// POST /api/admin/categories/reorder — 整批設定 parent 與排序（拖拉排序）
+func (h *CategoryHandler) Reorder(c *gin.Context) {
+	var req dto.ReorderCategoriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("請求格式錯誤: "+err.Error()))
+		return
+	}
+	cats, err := h.categorySvc.Reorder(req)
+	if err != nil {
+		handleErr(c, err, "排序失敗")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok(cats, "分類排序已更新"))
+}
+
 // DELETE /api/admin/categories/:id
 //
 // 刪除前會把該分類下的文章 reassign 到「未分類」分類，
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"github.com/paulhuang/paulfun-blogger/internal/services"
+	"github.com/paulhuang/paulfun-blogger/internal/spam"
+)
+
+// CommentHandler 讀者留言（公開）與審核佇列（admin）。
+type CommentHandler struct {
+	commentSvc *services.CommentService
+	spamSvc    *services.SpamService
+}
+
+func NewCommentHandler(commentSvc *services.CommentService, spamSvc *services.SpamService) *CommentHandler {
+	return &CommentHandler{commentSvc: commentSvc, spamSvc: spamSvc}
+}
+
+// GET /api/articles/:id/comments（公開，僅已審核通過的留言）
+func (h *CommentHandler) List(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("無效的文章 ID"))
+		return
+	}
+
+	var q dto.CommentQueryParams
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("查詢參數錯誤"))
+		return
+	}
+
+	result, err := h.commentSvc.ListPublic(id, q)
+	if err != nil {
+		handleErr(c, err, "查詢留言失敗")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.Ok(result, ""))
+}
+
+// POST /api/articles/:id/comments（公開，送出後進入審核佇列）
+func (h *CommentHandler) Create(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("無效的文章 ID"))
+		return
+	}
+
+	var req dto.CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("請求格式錯誤"))
+		return
+	}
+
+	email := ""
+	if req.AuthorEmail != nil {
+		email = *req.AuthorEmail
+	}
+	sub := spam.Submission{
+		Kind:        spam.KindComment,
+		ArticleID:   id,
+		IP:          c.ClientIP(),
+		UserAgent:   c.Request.UserAgent(),
+		AuthorName:  req.AuthorName,
+		AuthorEmail: email,
+		Content:     req.Content,
+		Honeypot:    req.Website,
+		FormToken:   req.FormToken,
+	}
+	src := services.CommentSource{
+		IP:        sub.IP,
+		UserAgent: sub.UserAgent,
+		Screen:    func() bool { return h.spamSvc.Check(sub).Spam },
+	}
+
+	comment, err := h.commentSvc.Create(id, req, src)
+	if err != nil {
+		handleErr(c, err, "留言失敗")
+		return
+	}
+	h.spamSvc.Record(sub)
+
+	c.JSON(http.StatusCreated, dto.Ok(comment, "留言已送出，審核通過後顯示"))
+}
+
+// GET /api/admin/comments?status=pending&articleId=
+func (h *CommentHandler) ListForModeration(c *gin.Context) {
+	var q dto.AdminCommentQueryParams
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("查詢參數錯誤"))
+		return
+	}
+
+	result, err := h.commentSvc.ListForModeration(q)
+	if err != nil {
+		handleErr(c, err, "查詢失敗")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.Ok(result, ""))
+}
+
+// POST /api/admin/comments/:id/approve
+func (h *CommentHandler) Approve(c *gin.Context) {
+	h.moderate(c, models.CommentStatusApproved, "留言已核准")
+}
+
+// POST /api/admin/comments/:id/reject
+func (h *CommentHandler) Reject(c *gin.Context) {
+	h.moderate(c, models.CommentStatusRejected, "留言已退回")
+}
+
+// POST /api/admin/comments/:id/spam
+func (h *CommentHandler) Spam(c *gin.Context) {
+	h.moderate(c, models.CommentStatusSpam, "留言已標記為垃圾訊息")
+}
+
+func (h *CommentHandler) moderate(c *gin.Context, status, msg string) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.Fail[any]("未登入"))
+		return
+	}
+
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("ID 格式錯誤"))
+		return
+	}
+
+	comment, err := h.commentSvc.Moderate(id, status, userID)
+	if err != nil {
+		handleErr(c, err, "審核失敗")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.Ok(comment, msg))
+}
+
+// DELETE /api/admin/comments/:id — 永久刪除（連同底下回覆）
+func (h *CommentHandler) Delete(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("ID 格式錯誤"))
+		return
+	}
+
+	deleted, err := h.commentSvc.Delete(id)
+	if err != nil {
+		handleErr(c, err, "刪除失敗")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.Ok(dto.CommentDeleteResultDto{Deleted: deleted}, "留言已刪除"))
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/feed"
+	"github.com/paulhuang/paulfun-blogger/internal/services"
+)
+
+// FeedHandler 訂閱 feed（RSS / Atom / JSON Feed），公開。
+//
+// 共用 query：?category={slug}、?tag={slug}、?mode=summary（預設）| full。
+type FeedHandler struct {
+	feedSvc *services.FeedService
+}
+
+func NewFeedHandler(feedSvc *services.FeedService) *FeedHandler {
+	return &FeedHandler{feedSvc: feedSvc}
+}
+
+// GET /feed.xml — RSS 2.0
+func (h *FeedHandler) RSS(c *gin.Context) {
+	h.serve(c, "application/rss+xml; charset=utf-8", feed.RSS)
+}
+
+// GET /atom.xml — Atom 1.0
+func (h *FeedHandler) Atom(c *gin.Context) {
+	h.serve(c, "application/atom+xml; charset=utf-8", feed.Atom)
+}
+
+// GET /feed.json — JSON Feed 1.1
+func (h *FeedHandler) JSON(c *gin.Context) {
+	h.serve(c, "application/feed+json; charset=utf-8", feed.JSON)
+}
+
+func (h *FeedHandler) serve(c *gin.Context, contentType string, render func(*feed.Feed, string) ([]byte, error)) {
+	mode := c.DefaultQuery("mode", "summary")
+	if mode != "summary" && mode != "full" {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("mode 僅接受 summary / full"))
+		return
+	}
+
+	f, err := h.feedSvc.Load(services.FeedQuery{
+		Category: c.Query("category"),
+		Tag:      c.Query("tag"),
+		Full:     mode == "full",
+	})
+	if err != nil {
+		handleErr(c, err, "產生 feed 失敗")
+		return
+	}
+
+	body, err := render(f, h.feedSvc.URL(c.Request.URL.RequestURI()))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.Fail[any]("產生 feed 失敗"))
+		return
+	}
+	writeCacheable(c, contentType, body, h.feedSvc.LastModified(f))
+}
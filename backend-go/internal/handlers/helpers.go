@@ -1,13 +1,18 @@
 package handlers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/paulhuang/paulfun-blogger/internal/apierror"
 	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/fingerprint"
 )
 
 // parseUintParam 從 URL param 解析 uint ID。
@@ -19,6 +24,29 @@ func parseUintParam(c *gin.Context, key string) (uint, error) {
 	return uint(val), nil
 }
 
+// expectedVersionFromRequest 決定樂觀鎖的預期版本：body 欄位優先，其次 If-Match header。
+// If-Match 接受 `"3"`、`W/"3"` 或 `3`；`*` 視為不檢查。格式錯誤回 error。
+func expectedVersionFromRequest(c *gin.Context, bodyVersion *int) (*int, error) {
+	if bodyVersion != nil {
+		return bodyVersion, nil
+	}
+	raw := strings.TrimSpace(c.GetHeader("If-Match"))
+	if raw == "" || raw == "*" {
+		return nil, nil
+	}
+	raw = strings.Trim(strings.TrimPrefix(raw, "W/"), `"`)
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// setVersionETag 以文章版本設定 ETag，供 client 之後以 If-Match 回傳。
+func setVersionETag(c *gin.Context, version int) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
 // getUserIDFromContext 從 JWT middleware 注入的 context 取出 userID。
 func getUserIDFromContext(c *gin.Context) (uint, bool) {
 	rawID, exists := c.Get("userID")
@@ -39,6 +67,17 @@ func getUserIDFromContext(c *gin.Context) (uint, bool) {
 // handleErr 將 service 層 sentinel error 映射到對應 HTTP 狀態碼並回傳 JSON。
 // 呼叫後應立即 return。
 func handleErr(c *gin.Context, err error, fallbackMsg string) {
+	// 版本衝突帶回目前版本，讓 client 可以重新載入後再送
+	var vc *apierror.VersionConflictError
+	if errors.As(err, &vc) {
+		c.JSON(http.StatusConflict, dto.ApiResponse[dto.VersionConflictDto]{
+			Success: false,
+			Data:    dto.VersionConflictDto{CurrentVersion: vc.Current},
+			Message: vc.Error(),
+		})
+		return
+	}
+
 	switch {
 	case errors.Is(err, apierror.ErrNotFound):
 		c.JSON(http.StatusNotFound, dto.Fail[any](err.Error()))
@@ -55,3 +94,46 @@ func handleErr(c *gin.Context, err error, fallbackMsg string) {
 		c.JSON(http.StatusInternalServerError, dto.Fail[any](fallbackMsg))
 	}
 }
+
+// writeCacheable 輸出可被快取的內容（feed / sitemap 等），附 ETag（內容 hash）與 Last-Modified。
+// 請求的 If-None-Match 或 If-Modified-Since 命中時回 304 不帶 body。lastModified 零值時不送 Last-Modified。
+func writeCacheable(c *gin.Context, contentType string, body []byte, lastModified time.Time) {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "public, max-age=300")
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if inm := c.GetHeader("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == etag || tag == "*" {
+				c.Status(http.StatusNotModified)
+				return
+			}
+		}
+	} else if ims := c.GetHeader("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		// HTTP 日期只到秒，比較前先截掉次秒
+		if t, err := http.ParseTime(ims); err == nil && !lastModified.Truncate(time.Second).After(t) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// identifyReader 識別匿名讀者；請求沒有有效 cookie 時順便回寫新的匿名 cookie。
+func identifyReader(c *gin.Context, readers *fingerprint.Identifier) fingerprint.Reader {
+	cookie, _ := c.Cookie(fingerprint.CookieName)
+	reader := readers.Identify(cookie, c.ClientIP(), c.Request.UserAgent())
+	if reader.NewCookie != "" {
+		secure := c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(fingerprint.CookieName, reader.NewCookie, fingerprint.CookieMaxAge, "/api", "", secure, true)
+	}
+	return reader
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/services"
+)
+
+// RankingHandler 前台熱門 / 趨勢文章排行。
+type RankingHandler struct {
+	svc *services.RankingService
+}
+
+func NewRankingHandler(svc *services.RankingService) *RankingHandler {
+	return &RankingHandler{svc: svc}
+}
+
+// GET /api/articles/popular?window=7d|30d|all&limit=
+func (h *RankingHandler) Popular(c *gin.Context) {
+	var q dto.PopularQueryParams
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("查詢參數錯誤"))
+		return
+	}
+
+	result, err := h.svc.Popular(q)
+	if err != nil {
+		handleErr(c, err, "查詢失敗")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.Ok(result, ""))
+}
+
+// GET /api/articles/trending?limit=
+func (h *RankingHandler) Trending(c *gin.Context) {
+	var q dto.TrendingQueryParams
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("查詢參數錯誤"))
+		return
+	}
+
+	result, err := h.svc.Trending(q)
+	if err != nil {
+		handleErr(c, err, "查詢失敗")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.Ok(result, ""))
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/scheduler"
+	"github.com/paulhuang/paulfun-blogger/internal/services"
+)
+
+// schedulerOverviewLimit 狀態 API 列出的排程文章 / 轉換紀錄筆數。
+const schedulerOverviewLimit = 20
+
+// SchedulerHandler 背景排程狀態查詢（admin）。
+type SchedulerHandler struct {
+	runner     *scheduler.Runner
+	articleSvc *services.ArticleService
+}
+
+func NewSchedulerHandler(runner *scheduler.Runner, articleSvc *services.ArticleService) *SchedulerHandler {
+	return &SchedulerHandler{runner: runner, articleSvc: articleSvc}
+}
+
+// GET /api/admin/scheduler/status
+func (h *SchedulerHandler) Status(c *gin.Context) {
+	upcoming, recent, err := h.articleSvc.GetSchedulerOverview(schedulerOverviewLimit)
+	if err != nil {
+		handleErr(c, err, "查詢排程狀態失敗")
+		return
+	}
+
+	statuses := h.runner.Status()
+	jobs := make([]dto.SchedulerJobDto, len(statuses))
+	for i, st := range statuses {
+		result := "ok"
+		switch {
+		case st.LastRunAt == nil:
+			result = "pending"
+		case st.LastError != "":
+			result = "error"
+		}
+		jobs[i] = dto.SchedulerJobDto{
+			Name:            st.Name,
+			IntervalSeconds: int(st.Interval.Seconds()),
+			Running:         st.Running,
+			RunCount:        st.RunCount,
+			LastRunAt:       st.LastRunAt,
+			LastDurationMs:  st.LastDuration.Milliseconds(),
+			LastResult:      result,
+			LastMessage:     st.LastMessage,
+			LastError:       st.LastError,
+			NextRunAt:       st.NextRunAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.Ok(dto.SchedulerStatusDto{
+		Jobs:              jobs,
+		Upcoming:          upcoming,
+		RecentTransitions: recent,
+	}, ""))
+}
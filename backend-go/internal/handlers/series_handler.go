@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/services"
+)
+
+// SeriesHandler 文章系列（公開瀏覽 + admin 管理）。
+type SeriesHandler struct {
+	svc *services.SeriesService
+}
+
+func NewSeriesHandler(svc *services.SeriesService) *SeriesHandler {
+	return &SeriesHandler{svc: svc}
+}
+
+// GET /api/series — 有已發佈文章的系列
+func (h *SeriesHandler) ListPublic(c *gin.Context) {
+	list, err := h.svc.ListPublic()
+	if err != nil {
+		handleErr(c, err, "查詢系列失敗")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok(list, ""))
+}
+
+// GET /api/series/:slug — 系列與已發佈文章（依順序）
+func (h *SeriesHandler) GetBySlug(c *gin.Context) {
+	series, err := h.svc.GetPublicBySlug(c.Param("slug"))
+	if err != nil {
+		handleErr(c, err, "系列不存在")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok(series, ""))
+}
+
+// GET /api/admin/series
+func (h *SeriesHandler) List(c *gin.Context) {
+	list, err := h.svc.List()
+	if err != nil {
+		handleErr(c, err, "查詢系列失敗")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok(list, ""))
+}
+
+// GET /api/admin/series/:id — 含草稿與垃圾桶中的成員
+func (h *SeriesHandler) Get(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("ID 格式錯誤"))
+		return
+	}
+	series, err := h.svc.Get(id)
+	if err != nil {
+		handleErr(c, err, "系列不存在")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok(series, ""))
+}
+
+// POST /api/admin/series
+func (h *SeriesHandler) Create(c *gin.Context) {
+	var req dto.CreateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("請求格式錯誤"))
+		return
+	}
+	series, err := h.svc.Create(req)
+	if err != nil {
+		handleErr(c, err, "建立系列失敗")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.Ok(series, "系列建立成功"))
+}
+
+// PUT /api/admin/series/:id
+func (h *SeriesHandler) Update(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("ID 格式錯誤"))
+		return
+	}
+	var req dto.UpdateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("請求格式錯誤"))
+		return
+	}
+	series, err := h.svc.Update(id, req)
+	if err != nil {
+		handleErr(c, err, "更新系列失敗")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok(series, "系列已更新"))
+}
+
+// DELETE /api/admin/series/:id — 只刪系列，文章不受影響
+func (h *SeriesHandler) Delete(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("ID 格式錯誤"))
+		return
+	}
+	if err := h.svc.Delete(id); err != nil {
+		handleErr(c, err, "刪除系列失敗")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok[any](nil, "系列已刪除"))
+}
+
+// POST /api/admin/series/:id/articles — 加入 / 移動文章（position 省略時接在最後）
+func (h *SeriesHandler) AddArticle(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("ID 格式錯誤"))
+		return
+	}
+	var req dto.AddSeriesArticleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("請求格式錯誤"))
+		return
+	}
+	series, err := h.svc.AddArticle(id, req)
+	if err != nil {
+		handleErr(c, err, "加入系列失敗")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok(series, "系列已更新"))
+}
+
+// DELETE /api/admin/series/:id/articles/:articleId
+func (h *SeriesHandler) RemoveArticle(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("ID 格式錯誤"))
+		return
+	}
+	articleID, err := parseUintParam(c, "articleId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("articleId 格式錯誤"))
+		return
+	}
+	series, err := h.svc.RemoveArticle(id, articleID)
+	if err != nil {
+		handleErr(c, err, "移出系列失敗")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok(series, "系列已更新"))
+}
+
+// PUT /api/admin/series/:id/articles/order — 整批重排
+func (h *SeriesHandler) Reorder(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("ID 格式錯誤"))
+		return
+	}
+	var req dto.ReorderSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("請求格式錯誤"))
+		return
+	}
+	series, err := h.svc.Reorder(id, req)
+	if err != nil {
+		handleErr(c, err, "重新排序失敗")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok(series, "系列已更新"))
+}
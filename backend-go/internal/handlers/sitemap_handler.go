@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paulhuang/paulfun-blogger/internal/apierror"
+	"github.com/paulhuang/paulfun-blogger/internal/services"
+)
+
+// SitemapHandler 搜尋引擎用的 sitemap 與 robots.txt，公開。
+type SitemapHandler struct {
+	sitemapSvc *services.SitemapService
+}
+
+func NewSitemapHandler(sitemapSvc *services.SitemapService) *SitemapHandler {
+	return &SitemapHandler{sitemapSvc: sitemapSvc}
+}
+
+const sitemapContentType = "application/xml; charset=utf-8"
+
+// GET /sitemap.xml — 網址數少時為 urlset，超過上限時為 sitemap index
+func (h *SitemapHandler) Root(c *gin.Context) {
+	doc, err := h.sitemapSvc.Root()
+	if err != nil {
+		handleErr(c, err, "產生 sitemap 失敗")
+		return
+	}
+	writeCacheable(c, sitemapContentType, doc.Body, doc.LastModified)
+}
+
+// GET /sitemaps/:file — sitemap index 的分頁，file 為 {n}.xml
+func (h *SitemapHandler) Page(c *gin.Context) {
+	name, ok := strings.CutSuffix(c.Param("file"), ".xml")
+	n, err := strconv.Atoi(name)
+	if !ok || err != nil {
+		handleErr(c, fmt.Errorf("%w: sitemap %q 不存在", apierror.ErrNotFound, c.Param("file")), "")
+		return
+	}
+	doc, err := h.sitemapSvc.Page(n)
+	if err != nil {
+		handleErr(c, err, "產生 sitemap 失敗")
+		return
+	}
+	writeCacheable(c, sitemapContentType, doc.Body, doc.LastModified)
+}
+
+// GET /robots.txt
+func (h *SitemapHandler) Robots(c *gin.Context) {
+	doc := h.sitemapSvc.Robots()
+	writeCacheable(c, "text/plain; charset=utf-8", doc.Body, doc.LastModified)
+}
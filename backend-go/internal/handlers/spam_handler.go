@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/services"
+)
+
+// SpamHandler 表單 token（公開）與垃圾訊息判定紀錄（admin）。
+type SpamHandler struct {
+	spamSvc *services.SpamService
+}
+
+func NewSpamHandler(spamSvc *services.SpamService) *SpamHandler {
+	return &SpamHandler{spamSvc: spamSvc}
+}
+
+// GET /api/form-token — 載入留言表單時取得，送出時放在 formToken
+func (h *SpamHandler) FormToken(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, dto.Ok(h.spamSvc.IssueToken(), ""))
+}
+
+// GET /api/admin/spam/decisions?verdict=&kind=&articleId=
+func (h *SpamHandler) ListDecisions(c *gin.Context) {
+	var q dto.SpamDecisionQueryParams
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("查詢參數錯誤"))
+		return
+	}
+
+	result, err := h.spamSvc.ListDecisions(q)
+	if err != nil {
+		handleErr(c, err, "查詢失敗")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.Ok(result, ""))
+}
+
+// GET /api/admin/spam/settings — 目前生效的策略與參數（唯讀，調整請改 Config）
+func (h *SpamHandler) Settings(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.Ok(h.spamSvc.Settings(), ""))
+}
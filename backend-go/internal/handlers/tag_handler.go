@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/services"
+)
+
+// TagHandler 處理後台標籤管理 API（需 JWT + admin）。
+type TagHandler struct {
+	tagSvc *services.TagService
+}
+
+func NewTagHandler(tagSvc *services.TagService) *TagHandler {
+	return &TagHandler{tagSvc: tagSvc}
+}
+
+// GET /api/admin/tags — 含各標籤文章數（不分狀態）
+func (h *TagHandler) List(c *gin.Context) {
+	tags, err := h.tagSvc.List()
+	if err != nil {
+		handleErr(c, err, "查詢失敗")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok(tags, ""))
+}
+
+// POST /api/admin/tags
+func (h *TagHandler) Create(c *gin.Context) {
+	var req dto.CreateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("請求格式錯誤: "+err.Error()))
+		return
+	}
+	tag, err := h.tagSvc.Create(req)
+	if err != nil {
+		handleErr(c, err, "建立失敗")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.Ok(tag, "標籤建立成功"))
+}
+
+// PUT /api/admin/tags/:id — 改名 / 改 slug
+func (h *TagHandler) Update(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("ID 格式錯誤"))
+		return
+	}
+	var req dto.UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("請求格式錯誤: "+err.Error()))
+		return
+	}
+	tag, err := h.tagSvc.Update(id, req)
+	if err != nil {
+		handleErr(c, err, "更新失敗")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok(tag, "標籤更新成功"))
+}
+
+// DELETE /api/admin/tags/:id — 同時移除所有文章上的此標籤
+func (h *TagHandler) Delete(c *gin.Context) {
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("ID 格式錯誤"))
+		return
+	}
+	if err := h.tagSvc.Delete(id); err != nil {
+		handleErr(c, err, "刪除失敗")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok[any](nil, "標籤刪除成功"))
+}
+
+// POST /api/admin/tags/merge — 把 sourceIds 併入 targetId 並刪除 source
+func (h *TagHandler) Merge(c *gin.Context) {
+	var req dto.MergeTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("請求格式錯誤: "+err.Error()))
+		return
+	}
+	result, err := h.tagSvc.Merge(req)
+	if err != nil {
+		handleErr(c, err, "合併失敗")
+		return
+	}
+	c.JSON(http.StatusOK, dto.Ok(result, "標籤合併成功"))
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/services"
+)
+
+// TrashHandler 文章垃圾桶（admin）。
+type TrashHandler struct {
+	articleSvc    *services.ArticleService
+	retentionDays int
+}
+
+func NewTrashHandler(articleSvc *services.ArticleService, retentionDays int) *TrashHandler {
+	return &TrashHandler{articleSvc: articleSvc, retentionDays: retentionDays}
+}
+
+// GET /api/admin/trash
+func (h *TrashHandler) List(c *gin.Context) {
+	var q dto.TrashQueryParams
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("查詢參數錯誤"))
+		return
+	}
+
+	result, err := h.articleSvc.ListTrash(q, h.retentionDays)
+	if err != nil {
+		handleErr(c, err, "查詢失敗")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.Ok(result, ""))
+}
+
+// POST /api/admin/trash/:id/restore
+func (h *TrashHandler) Restore(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.Fail[any]("未登入"))
+		return
+	}
+
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("ID 格式錯誤"))
+		return
+	}
+
+	article, err := h.articleSvc.RestoreFromTrash(id, userID)
+	if err != nil {
+		handleErr(c, err, "還原失敗")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.Ok(article, "文章已從垃圾桶還原"))
+}
+
+// DELETE /api/admin/trash/:id — 立即永久刪除（無法復原）
+func (h *TrashHandler) Purge(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.Fail[any]("未登入"))
+		return
+	}
+
+	id, err := parseUintParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.Fail[any]("ID 格式錯誤"))
+		return
+	}
+
+	if err := h.articleSvc.PurgeArticle(id, userID); err != nil {
+		handleErr(c, err, "永久刪除失敗")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.Ok(true, "文章已永久刪除"))
+}
+
+// POST /api/admin/trash/purge — 永久刪除超過保留期的文章（與背景 job 相同）
+func (h *TrashHandler) PurgeExpired(c *gin.Context) {
+	purged, err := h.articleSvc.PurgeExpiredTrash(h.retentionDays)
+	if err != nil {
+		handleErr(c, err, "清空垃圾桶失敗")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.Ok(dto.TrashPurgeResultDto{Purged: purged}, ""))
+}
@@ -30,17 +30,8 @@ func AuthRequired(jwtSecret string) gin.HandlerFunc {
 			return
 		}
 
-		tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
-		claims := &Claims{}
-
-		token, err := jwt.ParseWithClaims(tokenStr, claims, func(t *jwt.Token) (interface{}, error) {
-			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(jwtSecret), nil
-		})
-
-		if err != nil || !token.Valid {
+		claims, err := parseClaims(strings.TrimPrefix(authHeader, "Bearer "), jwtSecret)
+		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"success": false,
 				"message": "Token 無效或已過期",
@@ -56,6 +47,39 @@ func AuthRequired(jwtSecret string) gin.HandlerFunc {
 	}
 }
 
+// OptionalAuth 有合法 Bearer token 時注入 Claims（同 AuthRequired），沒有或無效時照常放行。
+// 用於公開端點需要分辨「已登入的作者 / 管理者」的情況（例如瀏覽數不計入後台預覽）。
+func OptionalAuth(jwtSecret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if strings.HasPrefix(authHeader, "Bearer ") {
+			if claims, err := parseClaims(strings.TrimPrefix(authHeader, "Bearer "), jwtSecret); err == nil {
+				c.Set("claims", claims)
+				c.Set("userID", claims.Sub)
+				c.Set("userRole", claims.Role)
+			}
+		}
+		c.Next()
+	}
+}
+
+func parseClaims(tokenStr, jwtSecret string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenStr, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(jwtSecret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	return claims, nil
+}
+
 // AdminRequired 需要 admin 角色（須在 AuthRequired 之後使用）
 func AdminRequired() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Article struct {
 	ID         uint       `gorm:"primaryKey;autoIncrement" json:"id"`
@@ -14,10 +18,14 @@ type Article struct {
 	Status     string     `gorm:"not null;default:'draft';size:20" json:"status"`
 	PublishedAt *time.Time `json:"publishedAt"`
 	ViewCount  int        `gorm:"default:0" json:"viewCount"`
-	LikeCount  int        `gorm:"default:0" json:"likeCount"`
+	LikeCount  int        `gorm:"default:0" json:"likeCount"` // = LikeBase + article_likes 筆數，由 reconcile job 校正
+	LikeBase   int        `gorm:"default:0" json:"-"`         // 去重機制上線前累積的匿名讚數
 	Version    int        `gorm:"default:1" json:"version"`
 	CreatedAt  time.Time  `json:"createdAt"`
 	UpdatedAt  *time.Time `json:"updatedAt"`
+	// DeletedAt 軟刪除（垃圾桶）；有值時 GORM 查詢預設排除。超過保留期由 purge job 真正刪除。
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+	DeletedBy  *uint          `json:"-"`
 
 	// Associations
 	Author   User      `gorm:"foreignKey:AuthorID" json:"author"`
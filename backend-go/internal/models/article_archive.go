@@ -10,6 +10,8 @@ type ArticleArchive struct {
 	Slug       string     `gorm:"not null;size:500" json:"slug"`
 	Summary    *string    `gorm:"type:text" json:"summary"`
 	Content    *string    `gorm:"type:text" json:"content"`
+	// ContentGzip 舊版本壓縮後的 content（retention job 產生）；有值時 Content 為 NULL。
+	ContentGzip []byte    `gorm:"type:bytea" json:"-"`
 	CoverImage *string    `gorm:"size:500" json:"coverImage"`
 	CategoryID *uint      `gorm:"index" json:"categoryId"`
 	Status     string     `gorm:"not null;size:20" json:"status"`
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// ArticleLike 一位匿名讀者對一篇文章的讚，(article_id, reader_id) 唯一，按讚 / 收回皆冪等。
+//
+// ReaderID 為匿名 cookie 推導出的 HMAC；NetworkHash 為 IP+UA 以輪替 salt 雜湊，
+// 每次按讚都會比對，擋下換新 cookie 的重複按讚（salt 換新後即無法再比對）。兩者皆不可回推原值。
+type ArticleLike struct {
+	ArticleID   uint      `gorm:"primaryKey;index:idx_article_likes_network,priority:1" json:"articleId"`
+	ReaderID    string    `gorm:"primaryKey;size:64" json:"-"`
+	NetworkHash string    `gorm:"not null;size:64;index:idx_article_likes_network,priority:2" json:"-"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
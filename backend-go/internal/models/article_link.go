@@ -6,8 +6,8 @@ import "time"
 //
 // relation 語意：
 //   - "related"：兩篇相關（無方向性，查詢時雙向收集）
-//   - "series"：演進系列，方向固定「新 → 舊」（from 承接 to），
-//     三部曲即一條 series 鏈，查詢時沿鏈雙向走訪重建完整系列。
+//   - "series"：舊制系列鏈（from 承接 to，新 → 舊）。系列已改為 Series / SeriesArticle，
+//     啟動時既有的 series link 會轉換後刪除，不再接受新建。
 type ArticleLink struct {
 	ID            uint      `gorm:"primaryKey;autoIncrement" json:"id"`
 	FromArticleID uint      `gorm:"not null;index;uniqueIndex:uq_article_link" json:"fromArticleId"`
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// ArticlePublishLog 記錄文章發佈狀態的每次轉換（誰、何時、從哪個狀態到哪個狀態）。
+//
+// Actor 格式：
+//   - "user:<id>"：後台手動發佈 / 取消發佈
+//   - "scheduler@<hostname>"：背景排程把到期的 scheduled 文章轉為 published
+type ArticlePublishLog struct {
+	ID          uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	ArticleID   uint       `gorm:"not null;index" json:"articleId"`
+	FromStatus  string     `gorm:"not null;size:20" json:"fromStatus"`
+	ToStatus    string     `gorm:"not null;size:20" json:"toStatus"`
+	Actor       string     `gorm:"not null;size:100" json:"actor"`
+	ScheduledAt *time.Time `json:"scheduledAt"` // 排程發佈時的原定時間
+	CreatedAt   time.Time  `gorm:"index" json:"createdAt"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// ArticleReaction 一位匿名讀者對一篇文章的某種表情回應。
+// 同一讀者可給多種回應，但每種只算一次；讀者識別方式同 ArticleLike。
+type ArticleReaction struct {
+	ArticleID   uint      `gorm:"primaryKey;index:idx_article_reactions_network,priority:1" json:"articleId"`
+	ReaderID    string    `gorm:"primaryKey;size:64" json:"-"`
+	Reaction    string    `gorm:"primaryKey;size:20;index" json:"reaction"` // 設定中的 reaction key，例如 thumbsup
+	NetworkHash string    `gorm:"not null;size:64;index:idx_article_reactions_network,priority:2" json:"-"`
+	CreatedAt   time.Time `gorm:"index" json:"createdAt"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"html"
+	"regexp"
+
+	"github.com/paulhuang/paulfun-blogger/internal/textseg"
+	"gorm.io/gorm"
+)
+
+// ArticleSearchIndexVersion search_vector 的建立規則版本。規則變更時 +1，
+// 啟動時會重算 search_version 較舊的 row（見 db.ensureArticleSearchIndex）。
+//
+//	1: PostgreSQL 'simple' parser 直接處理原文（中文無法斷詞）
+//	2: Go 端 textseg 斷詞（中文 bigram）後再建 tsvector
+//	3: 中文片段結尾字另存 unigram（單字查詢可命中結尾字）
+const ArticleSearchIndexVersion = 3
+
+// articleSearchVectorSQL 三個參數依序為斷詞後的 title / summary / content，
+// 各自標上權重 A / B / C，讓 ts_rank 依 title > summary > content 排序，
+// 也讓 tsquery 可用權重標籤限定欄位。'simple' 只轉小寫、不做 stemming。
+const articleSearchVectorSQL = `setweight(to_tsvector('simple', ?), 'A') ||
+	setweight(to_tsvector('simple', ?), 'B') ||
+	setweight(to_tsvector('simple', ?), 'C')`
+
+var searchHTMLTagRe = regexp.MustCompile(`<[^>]*>`)
+
+// AfterSave 文章寫入（Create / Save / Updates）後重算 search_vector。
+// search_vector 不在 struct 上，GORM 的 Save 不會覆寫它。
+// 注意：UpdateColumn / UpdateColumns 不觸發 hook，只能用在不影響 title / summary / content 的欄位。
+func (a *Article) AfterSave(tx *gorm.DB) error {
+	return UpdateArticleSearchVector(tx.Session(&gorm.Session{NewDB: true}), a.ID)
+}
+
+// UpdateArticleSearchVector 從 DB 讀出文章目前的文字欄位，斷詞後寫回 search_vector。
+func UpdateArticleSearchVector(db *gorm.DB, id uint) error {
+	var row struct {
+		Title   string
+		Summary *string
+		Content *string
+	}
+	if err := db.Table("articles").Select("title, summary, content").
+		Where("id = ?", id).Scan(&row).Error; err != nil {
+		return err
+	}
+
+	content := ""
+	if row.Content != nil {
+		content = html.UnescapeString(searchHTMLTagRe.ReplaceAllString(*row.Content, " "))
+	}
+	summary := ""
+	if row.Summary != nil {
+		summary = *row.Summary
+	}
+	return db.Exec("UPDATE articles SET search_vector = "+articleSearchVectorSQL+", search_version = ? WHERE id = ?",
+		textseg.Join(row.Title), textseg.Join(summary), textseg.Join(content),
+		ArticleSearchIndexVersion, id).Error
+}
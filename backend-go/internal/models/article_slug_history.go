@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// ArticleSlugHistory 文章舊 slug 紀錄。
+// 文章改 slug 時把舊值寫入此表，公開端以舊 slug 查詢會得到指向新 slug 的永久轉址，
+// 避免外部連結 / 搜尋引擎索引失效。slug 全域唯一，不可同時指向兩篇文章。
+type ArticleSlugHistory struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ArticleID uint      `gorm:"not null;index" json:"articleId"`
+	Slug      string    `gorm:"uniqueIndex;not null;size:500" json:"slug"`
+	CreatedAt time.Time `json:"createdAt"`
+}
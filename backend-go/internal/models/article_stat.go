@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// ArticleStat 文章每日統計，(article_id, day) 唯一；由 ViewTracker 批次累加。
+//
+// Day 以 UTC 日期為準。articles.view_count 仍是累計總數，兩者同一個交易內更新。
+type ArticleStat struct {
+	ArticleID uint      `gorm:"primaryKey" json:"articleId"`
+	Day       time.Time `gorm:"primaryKey;type:date;index" json:"day"`
+	Views     int       `gorm:"not null;default:0" json:"views"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
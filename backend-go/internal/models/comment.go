@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Comment 讀者留言。ParentID 非 nil 時為回覆（巢狀討論串）。
+//
+// 新留言一律進入 pending，經後台審核為 approved 才會公開；
+// 回覆的父留言必須屬於同一篇文章且已公開。
+type Comment struct {
+	ID          uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	ArticleID   uint       `gorm:"not null;index" json:"articleId"`
+	ParentID    *uint      `gorm:"index" json:"parentId"`
+	AuthorName  string     `gorm:"not null;size:50" json:"authorName"`
+	AuthorEmail *string    `gorm:"size:200" json:"-"` // 僅後台可見
+	Content     string     `gorm:"type:text;not null" json:"content"`
+	Status      string     `gorm:"not null;size:20;default:pending;index" json:"status"`
+	IPAddress   string     `gorm:"size:45" json:"-"`
+	UserAgent   string     `gorm:"size:300" json:"-"`
+	CreatedAt   time.Time  `gorm:"index" json:"createdAt"`
+	ModeratedAt *time.Time `json:"moderatedAt"`
+	ModeratedBy *uint      `json:"moderatedBy"`
+}
+
+const (
+	CommentStatusPending  = "pending"
+	CommentStatusApproved = "approved"
+	CommentStatusRejected = "rejected"
+	CommentStatusSpam     = "spam"
+)
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// PageViewDaily 讀者分析每日彙總：一列為某天（UTC）、某篇文章、某來源組合的瀏覽數。
+//
+// 來源組合 = referrer 主機 + utm_source/medium/campaign + 裝置類型，空字串代表無（直接造訪等）。
+// 不存 IP、完整網址或 User-Agent。Visitors 為當天首次造訪該文章的讀者數，
+// 記在該讀者第一次出現的來源列上，因此依文章 / 日期加總即為不重複讀者數。
+type PageViewDaily struct {
+	Day          time.Time `gorm:"primaryKey;type:date" json:"day"`
+	ArticleID    uint      `gorm:"primaryKey;index" json:"articleId"`
+	ReferrerHost string    `gorm:"primaryKey;size:100" json:"referrerHost"`
+	UTMSource    string    `gorm:"primaryKey;size:100" json:"utmSource"`
+	UTMMedium    string    `gorm:"primaryKey;size:100" json:"utmMedium"`
+	UTMCampaign  string    `gorm:"primaryKey;size:100" json:"utmCampaign"`
+	Device       string    `gorm:"primaryKey;size:10" json:"device"`
+	Views        int       `gorm:"not null;default:0" json:"views"`
+	Visitors     int       `gorm:"not null;default:0" json:"visitors"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+func (PageViewDaily) TableName() string { return "page_view_daily" }
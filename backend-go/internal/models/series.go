@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Series 文章系列（例如三部曲），有自己的標題、slug、描述與封面。
+// 成員與順序由 SeriesArticle 明確記錄，取代舊的 ArticleLink series 鏈。
+type Series struct {
+	ID          uint            `gorm:"primaryKey;autoIncrement" json:"id"`
+	Title       string          `gorm:"not null;size:200" json:"title"`
+	Slug        string          `gorm:"uniqueIndex;not null;size:200" json:"slug"`
+	Description *string         `gorm:"type:text" json:"description"`
+	CoverImage  *string         `gorm:"size:500" json:"coverImage"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+	Articles    []SeriesArticle `gorm:"foreignKey:SeriesID" json:"-"`
+}
+
+// SeriesArticle 系列成員。一篇文章最多屬於一個系列；Position 由 1 起，
+// 新增 / 移除 / 重排時整個系列重新編號（永久刪除文章時只刪該列，留下的空號不影響排序）。
+type SeriesArticle struct {
+	SeriesID  uint      `gorm:"primaryKey;index:idx_series_articles_position,priority:1" json:"seriesId"`
+	ArticleID uint      `gorm:"primaryKey;uniqueIndex" json:"articleId"`
+	Position  int       `gorm:"not null;index:idx_series_articles_position,priority:2" json:"position"`
+	Note      *string   `gorm:"size:200" json:"note"` // 承接前一篇的說明
+	CreatedAt time.Time `json:"createdAt"`
+
+	Article Article `gorm:"foreignKey:ArticleID" json:"-"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// SpamDecision 公開寫入端點的垃圾訊息判定紀錄，供後台檢視誤判。
+//
+// 判定為 spam 的一律記錄；放行的只在 Config SpamLogAllowed=true 時記錄。
+type SpamDecision struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Kind       string    `gorm:"not null;size:20;index" json:"kind"` // comment | like | reaction
+	ArticleID  uint      `gorm:"not null;index" json:"articleId"`
+	Verdict    string    `gorm:"not null;size:10;index" json:"verdict"` // spam | allow
+	Strategy   string    `gorm:"size:30" json:"strategy"`               // 判定為 spam 的策略
+	Reason     string    `gorm:"size:200" json:"reason"`
+	IPAddress  string    `gorm:"size:45" json:"ipAddress"`
+	UserAgent  string    `gorm:"size:300" json:"userAgent"`
+	AuthorName string    `gorm:"size:50" json:"authorName"`
+	Excerpt    string    `gorm:"size:200" json:"excerpt"` // 內容開頭
+	CreatedAt  time.Time `gorm:"index" json:"createdAt"`
+}
+
+const (
+	SpamVerdictSpam  = "spam"
+	SpamVerdictAllow = "allow"
+)
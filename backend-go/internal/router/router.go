@@ -19,6 +19,17 @@ type Handlers struct {
 	Category    *handlers.CategoryHandler
 	SATAdmin    *handlers.SATAdminHandler    // service-account-token 管理
 	ArticleLink *handlers.ArticleLinkHandler // 文章知識串連
+	Scheduler   *handlers.SchedulerHandler   // 背景排程狀態
+	Archive     *handlers.ArchiveHandler     // 歷史版本保留策略
+	Trash       *handlers.TrashHandler       // 文章垃圾桶
+	Tag         *handlers.TagHandler         // 標籤管理
+	Feed        *handlers.FeedHandler        // RSS / Atom / JSON Feed
+	Sitemap     *handlers.SitemapHandler     // sitemap.xml / robots.txt
+	Comment     *handlers.CommentHandler     // 讀者留言與審核
+	Spam        *handlers.SpamHandler        // 垃圾訊息檢查
+	Analytics   *handlers.AnalyticsHandler   // 讀者分析
+	Ranking     *handlers.RankingHandler     // 熱門 / 趨勢文章
+	Series      *handlers.SeriesHandler      // 文章系列
 }
 
 func Setup(cfg *config.Config, h Handlers, uploadDir string) *gin.Engine {
@@ -38,6 +49,16 @@ func Setup(cfg *config.Config, h Handlers, uploadDir string) *gin.Engine {
 		r.Static("/uploads", uploadDir)
 	}
 
+	// 訂閱 feed（nginx 需把這幾個路徑導到 Go server）
+	r.GET("/feed.xml", h.Feed.RSS)
+	r.GET("/atom.xml", h.Feed.Atom)
+	r.GET("/feed.json", h.Feed.JSON)
+
+	// sitemap / robots.txt（同樣需由 nginx 導到 Go server）
+	r.GET("/sitemap.xml", h.Sitemap.Root)
+	r.GET("/sitemaps/:file", h.Sitemap.Page)
+	r.GET("/robots.txt", h.Sitemap.Robots)
+
 	api := r.Group("/api")
 
 	// ── 認證（無需 token）────────────────────────────────────
@@ -50,17 +71,37 @@ func Setup(cfg *config.Config, h Handlers, uploadDir string) *gin.Engine {
 	}
 
 	// ── 前台公開 API ──────────────────────────────────────
+	api.GET("/form-token", h.Spam.FormToken) // 留言表單 token（最短送出時間檢查）
+	api.GET("/series", h.Series.ListPublic)
+	api.GET("/series/:slug", h.Series.GetBySlug)
+	beaconLimiter := middleware.NewRateLimiter(120, 1*time.Minute)
+	api.POST("/analytics/beacon", beaconLimiter.Limit(), h.Analytics.Beacon) // 讀者分析（無 cookie）
+
 	// 注意：固定路徑（categories, tags）必須在 /:id 之前（Gin 規則）
 	articles := api.Group("/articles")
-	likeLimiter := middleware.NewRateLimiter(60, 1*time.Minute) // 匿名按讚防濫用
+	likeLimiter := middleware.NewRateLimiter(60, 1*time.Minute)   // 匿名按讚 / 表情回應防濫用
+	searchLimiter := middleware.NewRateLimiter(30, 1*time.Minute) // 公開檢索（與後台檢索分開計算）
+	commentLimiter := middleware.NewRateLimiter(5, 1*time.Minute) // 匿名留言防洗版
 	{
 		articles.GET("", h.Article.ListArticles)
+		articles.GET("/search", searchLimiter.Limit(), h.Article.SearchArticles)
 		articles.GET("/categories", h.Article.ListCategories)
+		articles.GET("/categories/tree", h.Article.GetCategoryTree)
 		articles.GET("/tags", h.Article.ListTags)
-		articles.GET("/:id", h.Article.GetArticleByID)
-		articles.GET("/:id/related", h.ArticleLink.GetRelated) // 知識串連（series + related）
+		articles.GET("/popular", h.Ranking.Popular)   // ?window=7d|30d|all
+		articles.GET("/trending", h.Ranking.Trending) // 時間衰減的瀏覽 + 按讚
+		// OptionalAuth：已登入者（後台預覽）不計瀏覽數
+		articles.GET("/by-slug/:slug", middleware.OptionalAuth(cfg.JWTSecret), h.Article.GetArticleBySlug) // 舊 slug → 301 指向目前 slug
+		articles.GET("/:id", middleware.OptionalAuth(cfg.JWTSecret), h.Article.GetArticleByID)
+		articles.GET("/:id/related", h.ArticleLink.GetRelated) // 知識串連（series + related + suggested）
+		articles.GET("/:id/like", h.Article.GetLikeStatus)
 		articles.POST("/:id/like", likeLimiter.Limit(), h.Article.LikeArticle)
 		articles.POST("/:id/unlike", likeLimiter.Limit(), h.Article.UnlikeArticle)
+		articles.GET("/:id/reactions", h.Article.GetReactions)
+		articles.POST("/:id/reactions/:type", likeLimiter.Limit(), h.Article.AddReaction)
+		articles.DELETE("/:id/reactions/:type", likeLimiter.Limit(), h.Article.RemoveReaction)
+		articles.GET("/:id/comments", h.Comment.List)
+		articles.POST("/:id/comments", commentLimiter.Limit(), h.Comment.Create)
 	}
 
 	// ── 後台 API（需要認證 + admin 權限）──────────────────────
@@ -80,14 +121,58 @@ func Setup(cfg *config.Config, h Handlers, uploadDir string) *gin.Engine {
 		admin.DELETE("/articles/:id", h.Admin.DeleteArticle)
 		admin.POST("/articles/:id/publish", h.Admin.PublishArticle)
 		admin.POST("/articles/:id/unpublish", h.Admin.UnpublishArticle)
+		admin.GET("/articles/:id/stats", h.Admin.GetArticleViewStats) // ?days=30 每日瀏覽數
 		// Article Links（知識串連管理）
 		admin.GET("/articles/:id/links", h.ArticleLink.GetLinks)
 		admin.POST("/articles/:id/links", h.ArticleLink.CreateLink)
 		admin.DELETE("/articles/:id/links/:linkId", h.ArticleLink.DeleteLink)
+		admin.GET("/articles/:id/suggestions", h.ArticleLink.GetSuggestions)                       // 內容相似度推薦
+		admin.POST("/articles/:id/suggestions/:targetId/promote", h.ArticleLink.PromoteSuggestion) // 推薦 → related
 
 		admin.GET("/articles/:id/archives", h.Admin.GetArticleArchives)
+		admin.GET("/articles/:id/archives/diff", h.Admin.DiffArticleArchives) // ?from=&to=（archive ID 或 current）
 		admin.GET("/articles/:id/archives/:archiveId", h.Admin.GetArticleArchiveDetail)
 		admin.POST("/articles/:id/restore/:archiveId", h.Admin.RestoreArticle)
+		admin.POST("/archives/prune", h.Archive.Prune) // 保留策略（預設 dryRun）
+
+		// Trash（DELETE 文章後進垃圾桶，可還原；超過保留期由 job 永久刪除）
+		admin.GET("/trash", h.Trash.List)
+		admin.POST("/trash/purge", h.Trash.PurgeExpired)
+		admin.POST("/trash/:id/restore", h.Trash.Restore)
+		admin.DELETE("/trash/:id", h.Trash.Purge)
+
+		// Comments（審核佇列）
+		admin.GET("/comments", h.Comment.ListForModeration)
+		admin.POST("/comments/:id/approve", h.Comment.Approve)
+		admin.POST("/comments/:id/reject", h.Comment.Reject)
+		admin.POST("/comments/:id/spam", h.Comment.Spam)
+		admin.DELETE("/comments/:id", h.Comment.Delete)
+
+		// Reactions（表情回應統計）
+		admin.GET("/reactions/stats", h.Admin.ReactionStats)
+
+		// Spam（垃圾訊息判定紀錄）
+		admin.GET("/spam/decisions", h.Spam.ListDecisions)
+		admin.GET("/spam/settings", h.Spam.Settings)
+
+		// Knowledge graph（related 串連 + 系列的整體圖）
+		admin.GET("/graph", h.Admin.ExportGraph) // ?format=json|graphml|dot
+		admin.GET("/graph/stats", h.Admin.GraphStats)
+
+		// Series（文章系列與成員順序）
+		admin.GET("/series", h.Series.List)
+		admin.POST("/series", h.Series.Create)
+		admin.GET("/series/:id", h.Series.Get)
+		admin.PUT("/series/:id", h.Series.Update)
+		admin.DELETE("/series/:id", h.Series.Delete)
+		admin.POST("/series/:id/articles", h.Series.AddArticle) // 可指定插入位置
+		admin.PUT("/series/:id/articles/order", h.Series.Reorder)
+		admin.DELETE("/series/:id/articles/:articleId", h.Series.RemoveArticle)
+
+		// Analytics（讀者分析報表）
+		admin.GET("/analytics/top-articles", h.Analytics.TopArticles)
+		admin.GET("/analytics/top-sources", h.Analytics.TopSources)
+		admin.GET("/analytics/articles/:id", h.Analytics.ArticleSeries)
 
 		// Media
 		admin.GET("/media", h.Media.ListMedia)
@@ -102,15 +187,26 @@ func Setup(cfg *config.Config, h Handlers, uploadDir string) *gin.Engine {
 
 		// Categories CRUD（單筆建立 / 更新 / 刪除）
 		admin.POST("/categories", h.Category.Create)
+		admin.POST("/categories/reorder", h.Category.Reorder) // 整批 parent + 排序
 		admin.PUT("/categories/:id", h.Category.Update)
 		admin.DELETE("/categories/:id", h.Category.Delete)
 
+		// Tags CRUD + 合併
+		admin.GET("/tags", h.Tag.List)
+		admin.POST("/tags", h.Tag.Create)
+		admin.POST("/tags/merge", h.Tag.Merge)
+		admin.PUT("/tags/:id", h.Tag.Update)
+		admin.DELETE("/tags/:id", h.Tag.Delete)
+
 		// Service Account Tokens（spec v3 §2.11）
 		// SAT-issued JWT 在 handler 入口被 rejectSATSource 擋下（R7）
 		admin.GET("/service-account-tokens", h.SATAdmin.List)
 		admin.POST("/service-account-tokens", h.SATAdmin.Create)
 		admin.PATCH("/service-account-tokens/:id", h.SATAdmin.Update)
 		admin.DELETE("/service-account-tokens/:id", h.SATAdmin.Delete)
+
+		// Scheduler（背景排程狀態）
+		admin.GET("/scheduler/status", h.Scheduler.Status)
 	}
 
 	return r
@@ -0,0 +1,150 @@
+// Package scheduler 提供 in-process 背景工作執行器。
+//
+// 每個 Job 以固定間隔在獨立 goroutine 執行，啟動時先立即跑一次
+// （補上 server 停機期間錯過的工作）。Runner 本身不做跨副本協調，
+// 多副本部署時的互斥由各 Job 在 DB 層自行處理（例如 row lock）。
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// JobFunc 背景工作本體；回傳的字串為本次執行摘要（顯示於狀態 API）。
+type JobFunc func(ctx context.Context) (string, error)
+
+// JobStatus 單一 Job 的執行狀態快照。
+type JobStatus struct {
+	Name         string
+	Interval     time.Duration
+	Running      bool
+	RunCount     int
+	LastRunAt    *time.Time
+	LastDuration time.Duration
+	LastMessage  string
+	LastError    string
+	NextRunAt    *time.Time
+}
+
+type job struct {
+	name     string
+	interval time.Duration
+	fn       JobFunc
+
+	mu     sync.Mutex
+	status JobStatus
+}
+
+// Runner 管理所有註冊的背景工作。
+type Runner struct {
+	mu      sync.Mutex
+	jobs    []*job
+	started bool
+}
+
+func New() *Runner {
+	return &Runner{}
+}
+
+// Register 註冊背景工作；必須在 Start 之前呼叫。
+func (r *Runner) Register(name string, interval time.Duration, fn JobFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.started {
+		log.Printf("[scheduler] job %q 在 Start 之後註冊，已忽略", name)
+		return
+	}
+	r.jobs = append(r.jobs, &job{
+		name:     name,
+		interval: interval,
+		fn:       fn,
+		status:   JobStatus{Name: name, Interval: interval},
+	})
+}
+
+// Start 為每個 Job 啟動獨立 goroutine；ctx 取消時全部停止。
+func (r *Runner) Start(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.started {
+		return
+	}
+	r.started = true
+	for _, j := range r.jobs {
+		go j.loop(ctx)
+		log.Printf("[scheduler] job %q 已啟動（每 %s）", j.name, j.interval)
+	}
+}
+
+// Status 回傳所有 Job 的狀態快照（依註冊順序）。
+func (r *Runner) Status() []JobStatus {
+	r.mu.Lock()
+	jobs := append([]*job(nil), r.jobs...)
+	r.mu.Unlock()
+
+	out := make([]JobStatus, len(jobs))
+	for i, j := range jobs {
+		j.mu.Lock()
+		out[i] = j.status
+		j.mu.Unlock()
+	}
+	return out
+}
+
+func (j *job) loop(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.run(ctx)
+		}
+	}
+}
+
+// run 執行一次 Job；panic 會被攔下並記為錯誤，不影響後續排程。
+func (j *job) run(ctx context.Context) {
+	j.mu.Lock()
+	j.status.Running = true
+	j.mu.Unlock()
+
+	start := time.Now().UTC()
+	var (
+		msg string
+		err error
+	)
+	func() {
+		defer func() {
+			if p := recover(); p != nil {
+				err = fmt.Errorf("panic: %v", p)
+			}
+		}()
+		msg, err = j.fn(ctx)
+	}()
+	duration := time.Since(start)
+	next := time.Now().UTC().Add(j.interval)
+
+	j.mu.Lock()
+	j.status.Running = false
+	j.status.RunCount++
+	j.status.LastRunAt = &start
+	j.status.LastDuration = duration
+	j.status.LastMessage = msg
+	j.status.LastError = ""
+	if err != nil {
+		j.status.LastError = err.Error()
+	}
+	j.status.NextRunAt = &next
+	j.mu.Unlock()
+
+	if err != nil {
+		log.Printf("[scheduler] job %q 失敗: %v", j.name, err)
+	}
+}
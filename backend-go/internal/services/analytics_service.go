@@ -0,0 +1,427 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/paulhuang/paulfun-blogger/internal/analytics"
+	"github.com/paulhuang/paulfun-blogger/internal/apierror"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	analyticsDefaultDays = 30
+	analyticsMaxDays     = 366
+	analyticsBufferSize  = 4096
+)
+
+// AnalyticsSettings 讀者分析設定（來自 Config）。
+type AnalyticsSettings struct {
+	Enabled       bool
+	FlushInterval time.Duration
+}
+
+// PageViewEvent beacon 整理後的一次頁面瀏覽。Visitor 為不需 cookie 的 IP+UA 雜湊（fingerprint.NetworkHash）。
+type PageViewEvent struct {
+	ArticleID   uint
+	Referrer    string
+	UTMSource   string
+	UTMMedium   string
+	UTMCampaign string
+	UserAgent   string
+	Visitor     string
+}
+
+// pageViewKey 彙總單位，對應 page_view_daily 的主鍵。
+type pageViewKey struct {
+	day         string // 2006-01-02
+	articleID   uint
+	referrer    string
+	utmSource   string
+	utmMedium   string
+	utmCampaign string
+	device      string
+}
+
+// pageViewHit 佇列中的一次瀏覽；newVisitor 為讀者今天第一次看這篇。
+type pageViewHit struct {
+	key        pageViewKey
+	newVisitor bool
+}
+
+type pageViewCount struct {
+	views    int
+	visitors int
+}
+
+// AnalyticsService 收集 beacon 並定期批次寫入 page_view_daily，提供後台排行與時間序列。
+//
+// 不發 cookie、不存 IP：讀者以 IP+UA 的輪替 salt 雜湊辨識，只用來在記憶體內判斷
+// 「今天是否第一次看這篇」，不落地。重啟後當天已出現過的讀者會再算一次。
+type AnalyticsService struct {
+	db       *gorm.DB
+	settings AnalyticsSettings
+	siteHost string
+	events   chan pageViewHit
+	done     chan struct{}
+
+	mu     sync.Mutex
+	seen   map[string]struct{} // day|articleID|visitor
+	closed bool
+}
+
+func NewAnalyticsService(db *gorm.DB, baseURL string, settings AnalyticsSettings) *AnalyticsService {
+	if settings.FlushInterval <= 0 {
+		settings.FlushInterval = 30 * time.Second
+	}
+	siteHost := ""
+	if u, err := url.Parse(baseURL); err == nil {
+		siteHost = analytics.NormalizeHost(u.Hostname())
+	}
+	return &AnalyticsService{
+		db:       db,
+		settings: settings,
+		siteHost: siteHost,
+		events:   make(chan pageViewHit, analyticsBufferSize),
+		done:     make(chan struct{}),
+		seen:     make(map[string]struct{}),
+	}
+}
+
+// Enabled 是否收集 beacon。
+func (s *AnalyticsService) Enabled() bool {
+	return s.settings.Enabled
+}
+
+// Start 啟動背景寫入 goroutine；只能呼叫一次。
+func (s *AnalyticsService) Start() {
+	go s.loop()
+}
+
+// Close 停止收集，寫完佇列中剩餘的事件後返回。
+func (s *AnalyticsService) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	close(s.events)
+	s.mu.Unlock()
+	<-s.done
+}
+
+// Collect 登記一次頁面瀏覽（非阻塞），回傳是否收下。停用、爬蟲、佇列已滿時不收。
+func (s *AnalyticsService) Collect(ev PageViewEvent) bool {
+	if !s.settings.Enabled || ev.ArticleID == 0 || IsBotUserAgent(ev.UserAgent) {
+		return false
+	}
+	key := pageViewKey{
+		day:         time.Now().UTC().Format("2006-01-02"),
+		articleID:   ev.ArticleID,
+		referrer:    analytics.ReferrerHost(ev.Referrer, s.siteHost),
+		utmSource:   analytics.UTMValue(ev.UTMSource),
+		utmMedium:   analytics.UTMValue(ev.UTMMedium),
+		utmCampaign: analytics.UTMValue(ev.UTMCampaign),
+		device:      analytics.DeviceClass(ev.UserAgent),
+	}
+
+	visitorID := fmt.Sprintf("%s|%d|%s", key.day, key.articleID, ev.Visitor)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	_, seen := s.seen[visitorID]
+	select {
+	case s.events <- pageViewHit{key: key, newVisitor: !seen}:
+		s.seen[visitorID] = struct{}{}
+		return true
+	default:
+		log.Printf("[analytics] 佇列已滿，丟棄 article_id=%d 的 beacon", ev.ArticleID)
+		return false
+	}
+}
+
+func (s *AnalyticsService) loop() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.settings.FlushInterval)
+	defer ticker.Stop()
+
+	pending := make(map[pageViewKey]*pageViewCount)
+	for {
+		select {
+		case hit, ok := <-s.events:
+			if !ok {
+				s.flush(pending)
+				return
+			}
+			c := pending[hit.key]
+			if c == nil {
+				c = &pageViewCount{}
+				pending[hit.key] = c
+			}
+			c.views++
+			if hit.newVisitor {
+				c.visitors++
+			}
+		case <-ticker.C:
+			s.flush(pending)
+			s.sweep()
+		}
+	}
+}
+
+// flush 寫入累積的數字；失敗時保留在 pending 等下一輪重試。
+// 只寫入仍存在且已發佈的文章（beacon 的 articleId 由前台送出，不可信）。
+func (s *AnalyticsService) flush(pending map[pageViewKey]*pageViewCount) {
+	if len(pending) == 0 {
+		return
+	}
+	idSet := make(map[uint]struct{})
+	for k := range pending {
+		idSet[k.articleID] = struct{}{}
+	}
+	ids := make([]uint, 0, len(idSet))
+	for id := range idSet {
+		ids = append(ids, id)
+	}
+
+	var published []uint
+	if err := s.db.Model(&models.Article{}).
+		Where("id IN ? AND status = ?", ids, "published").
+		Pluck("id", &published).Error; err != nil {
+		log.Printf("[analytics] 查詢文章失敗（下一輪重試）: %v", err)
+		return
+	}
+	alive := make(map[uint]bool, len(published))
+	for _, id := range published {
+		alive[id] = true
+	}
+
+	now := time.Now()
+	rows := make([]models.PageViewDaily, 0, len(pending))
+	for k, c := range pending {
+		if !alive[k.articleID] {
+			continue
+		}
+		day, _ := time.Parse("2006-01-02", k.day)
+		rows = append(rows, models.PageViewDaily{
+			Day:          day,
+			ArticleID:    k.articleID,
+			ReferrerHost: k.referrer,
+			UTMSource:    k.utmSource,
+			UTMMedium:    k.utmMedium,
+			UTMCampaign:  k.utmCampaign,
+			Device:       k.device,
+			Views:        c.views,
+			Visitors:     c.visitors,
+			UpdatedAt:    now,
+		})
+	}
+	if len(rows) > 0 {
+		err := s.db.Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "day"}, {Name: "article_id"}, {Name: "referrer_host"},
+				{Name: "utm_source"}, {Name: "utm_medium"}, {Name: "utm_campaign"}, {Name: "device"},
+			},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"views":      gorm.Expr("page_view_daily.views + EXCLUDED.views"),
+				"visitors":   gorm.Expr("page_view_daily.visitors + EXCLUDED.visitors"),
+				"updated_at": gorm.Expr("EXCLUDED.updated_at"),
+			}),
+		}).CreateInBatches(&rows, 500).Error
+		if err != nil {
+			log.Printf("[analytics] 寫入彙總失敗（%d 列，下一輪重試）: %v", len(rows), err)
+			return
+		}
+	}
+	for k := range pending {
+		delete(pending, k)
+	}
+}
+
+// sweep 清掉前一天（含）以前的讀者紀錄。
+func (s *AnalyticsService) sweep() {
+	today := time.Now().UTC().Format("2006-01-02")
+	s.mu.Lock()
+	for id := range s.seen {
+		if !strings.HasPrefix(id, today+"|") {
+			delete(s.seen, id)
+		}
+	}
+	s.mu.Unlock()
+}
+
+// ── 後台查詢 ─────────────────────────────────────────────
+
+// parseAnalyticsRange 解析日期區間；未指定 to 為今天，未指定 from 為 to 往前 29 天。
+func parseAnalyticsRange(q dto.AnalyticsRangeParams) (time.Time, time.Time, error) {
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if q.To != "" {
+		t, err := time.Parse("2006-01-02", q.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: to 格式須為 YYYY-MM-DD", apierror.ErrBadRequest)
+		}
+		to = t
+	}
+	from := to.AddDate(0, 0, -(analyticsDefaultDays - 1))
+	if q.From != "" {
+		f, err := time.Parse("2006-01-02", q.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: from 格式須為 YYYY-MM-DD", apierror.ErrBadRequest)
+		}
+		from = f
+	}
+	if from.After(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: from 不可晚於 to", apierror.ErrBadRequest)
+	}
+	if int(to.Sub(from).Hours()/24)+1 > analyticsMaxDays {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: 區間最長 %d 天", apierror.ErrBadRequest, analyticsMaxDays)
+	}
+	return from, to, nil
+}
+
+func rangeDto(from, to time.Time) dto.AnalyticsRangeDto {
+	return dto.AnalyticsRangeDto{From: from.Format("2006-01-02"), To: to.Format("2006-01-02")}
+}
+
+// TopArticles 區間內瀏覽數最多的文章（含已下架 / 垃圾桶中的文章，標題照列）。
+func (s *AnalyticsService) TopArticles(q dto.TopArticlesQueryParams) (*dto.TopArticlesDto, error) {
+	from, to, err := parseAnalyticsRange(q.AnalyticsRangeParams)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []dto.TopArticleDto
+	if err := s.db.Raw(`
+		SELECT p.article_id, a.title, a.slug, SUM(p.views) AS views, SUM(p.visitors) AS visitors
+		FROM page_view_daily p
+		JOIN articles a ON a.id = p.article_id
+		WHERE p.day BETWEEN ? AND ?
+		GROUP BY p.article_id, a.title, a.slug
+		ORDER BY views DESC, p.article_id DESC
+		LIMIT ?`, from, to, q.GetLimit()).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if rows == nil {
+		rows = []dto.TopArticleDto{}
+	}
+	return &dto.TopArticlesDto{Range: rangeDto(from, to), Articles: rows}, nil
+}
+
+// sourceColumns TopSources 的 by 參數對應欄位。
+var sourceColumns = map[string]string{
+	"referrer": "referrer_host",
+	"source":   "utm_source",
+	"medium":   "utm_medium",
+	"campaign": "utm_campaign",
+	"device":   "device",
+}
+
+// TopSources 依來源維度排行。
+func (s *AnalyticsService) TopSources(q dto.TopSourcesQueryParams) (*dto.TopSourcesDto, error) {
+	from, to, err := parseAnalyticsRange(q.AnalyticsRangeParams)
+	if err != nil {
+		return nil, err
+	}
+	by := q.By
+	if by == "" {
+		by = "referrer"
+	}
+	if _, ok := sourceColumns[by]; !ok {
+		return nil, fmt.Errorf("%w: by 僅接受 referrer / source / medium / campaign / device", apierror.ErrBadRequest)
+	}
+
+	rows, err := s.sourceStats(by, from, to, q.ArticleID, q.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+	return &dto.TopSourcesDto{Range: rangeDto(from, to), By: by, Sources: rows}, nil
+}
+
+func (s *AnalyticsService) sourceStats(by string, from, to time.Time, articleID uint, limit int) ([]dto.SourceStatDto, error) {
+	col := sourceColumns[by]
+	query := s.db.Model(&models.PageViewDaily{}).
+		Select(col+" AS key, SUM(views) AS views, SUM(visitors) AS visitors").
+		Where("day BETWEEN ? AND ?", from, to)
+	if articleID != 0 {
+		query = query.Where("article_id = ?", articleID)
+	}
+
+	var rows []dto.SourceStatDto
+	if err := query.Group(col).Order("views DESC, key").Limit(limit).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].Label = sourceLabel(by, rows[i].Key)
+	}
+	if rows == nil {
+		rows = []dto.SourceStatDto{}
+	}
+	return rows, nil
+}
+
+func sourceLabel(by, key string) string {
+	switch {
+	case key != "":
+		return key
+	case by == "referrer":
+		return "(direct)"
+	default:
+		return "(none)"
+	}
+}
+
+// ArticleSeries 單篇文章區間內的每日數字與來源摘要。
+func (s *AnalyticsService) ArticleSeries(id uint, q dto.AnalyticsRangeParams) (*dto.ArticleAnalyticsDto, error) {
+	from, to, err := parseAnalyticsRange(q)
+	if err != nil {
+		return nil, err
+	}
+	var article models.Article
+	if err := s.db.Unscoped().Select("id", "title").First(&article, id).Error; err != nil {
+		return nil, fmt.Errorf("%w: 文章不存在", apierror.ErrNotFound)
+	}
+
+	var daily []struct {
+		Day      time.Time
+		Views    int
+		Visitors int
+	}
+	if err := s.db.Model(&models.PageViewDaily{}).
+		Select("day, SUM(views) AS views, SUM(visitors) AS visitors").
+		Where("article_id = ? AND day BETWEEN ? AND ?", id, from, to).
+		Group("day").Scan(&daily).Error; err != nil {
+		return nil, err
+	}
+	byDay := make(map[string]pageViewCount, len(daily))
+	for _, d := range daily {
+		byDay[d.Day.UTC().Format("2006-01-02")] = pageViewCount{views: d.Views, visitors: d.Visitors}
+	}
+
+	result := &dto.ArticleAnalyticsDto{ArticleID: article.ID, Title: article.Title, Range: rangeDto(from, to)}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		c := byDay[date]
+		result.Days = append(result.Days, dto.AnalyticsDayDto{Date: date, Views: c.views, Visitors: c.visitors})
+		result.Views += c.views
+		result.Visitors += c.visitors
+	}
+
+	if result.Referrers, err = s.sourceStats("referrer", from, to, id, 10); err != nil {
+		return nil, err
+	}
+	if result.Devices, err = s.sourceStats("device", from, to, id, 10); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"gorm.io/gorm"
+)
+
+// ArchiveRetentionPolicy article_archives 保留策略。
+//
+//   - KeepLast：每篇文章最新的 N 個版本一律保留
+//   - DailyAfterDays：超過 X 天的版本每天只留最新一個；X 天內全部保留
+//   - CompressAfterDays：保留下來且超過 Y 天的版本把 content gzip 壓縮（0 = 不壓縮）
+//
+// 曾經是 published 狀態的版本永不刪除（對外發佈過的內容需可追溯）。
+type ArchiveRetentionPolicy struct {
+	KeepLast          int
+	DailyAfterDays    int
+	CompressAfterDays int
+}
+
+// ArchiveRetentionService 套用保留策略清理 / 壓縮 article_archives。
+type ArchiveRetentionService struct {
+	db     *gorm.DB
+	policy ArchiveRetentionPolicy
+}
+
+func NewArchiveRetentionService(db *gorm.DB, policy ArchiveRetentionPolicy) *ArchiveRetentionService {
+	return &ArchiveRetentionService{db: db, policy: policy}
+}
+
+// Policy 回傳預設策略（來自 Config）。
+func (s *ArchiveRetentionService) Policy() ArchiveRetentionPolicy {
+	return s.policy
+}
+
+// archiveMeta 不含 content 的 archive 摘要，避免一次載入大量全文。
+type archiveMeta struct {
+	ID           uint
+	ArticleID    uint
+	Status       string
+	ArchivedAt   time.Time
+	Compressed   bool
+	ContentBytes int64
+}
+
+// Apply 依 policy 計算並（dryRun=false 時）執行刪除與壓縮。
+// articleID 非 nil 時只處理該篇文章。
+func (s *ArchiveRetentionService) Apply(policy ArchiveRetentionPolicy, articleID *uint, dryRun bool) (*dto.ArchivePruneReportDto, error) {
+	q := s.db.Model(&models.ArticleArchive{}).
+		Select("id, article_id, status, archived_at, content_gzip IS NOT NULL AS compressed, COALESCE(octet_length(content), 0) AS content_bytes").
+		Order("article_id ASC, archived_at DESC, id DESC")
+	if articleID != nil {
+		q = q.Where("article_id = ?", *articleID)
+	}
+	var metas []archiveMeta
+	if err := q.Scan(&metas).Error; err != nil {
+		return nil, err
+	}
+
+	report := &dto.ArchivePruneReportDto{
+		DryRun: dryRun,
+		Policy: dto.ArchiveRetentionPolicyDto{
+			KeepLast:          policy.KeepLast,
+			DailyAfterDays:    policy.DailyAfterDays,
+			CompressAfterDays: policy.CompressAfterDays,
+		},
+		ArchivesScanned: len(metas),
+		Articles:        []dto.ArchivePruneArticleDto{},
+	}
+
+	now := time.Now().UTC()
+	for start := 0; start < len(metas); {
+		end := start
+		for end < len(metas) && metas[end].ArticleID == metas[start].ArticleID {
+			end++
+		}
+		report.ArticlesScanned++
+
+		del, comp, compBytes := planRetention(metas[start:end], policy, now)
+		if len(del) > 0 || len(comp) > 0 {
+			report.Articles = append(report.Articles, dto.ArchivePruneArticleDto{
+				ArticleID: metas[start].ArticleID,
+				Delete:    del,
+				Compress:  comp,
+			})
+			report.Deleted += len(del)
+			report.Compressed += len(comp)
+			report.BytesToCompress += compBytes
+		}
+		start = end
+	}
+
+	if dryRun {
+		return report, nil
+	}
+
+	// 每篇文章的刪除與壓縮在同一個交易內：失敗時該篇維持原狀，不會只套用一半
+	for _, a := range report.Articles {
+		var saved int64
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			if len(a.Delete) > 0 {
+				if err := tx.Where("id IN ?", a.Delete).Delete(&models.ArticleArchive{}).Error; err != nil {
+					return err
+				}
+			}
+			for _, id := range a.Compress {
+				n, err := compressArchive(tx, id)
+				if err != nil {
+					return err
+				}
+				saved += n
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("文章 %d 套用保留策略失敗: %w", a.ArticleID, err)
+		}
+		report.BytesSaved += saved
+	}
+	if report.Deleted > 0 || report.Compressed > 0 {
+		log.Printf("[archive] retention applied: deleted=%d compressed=%d bytes_saved=%d",
+			report.Deleted, report.Compressed, report.BytesSaved)
+	}
+	return report, nil
+}
+
+// planRetention 對單篇文章的 archives（新 → 舊）決定要刪除與壓縮的版本。
+func planRetention(metas []archiveMeta, policy ArchiveRetentionPolicy, now time.Time) (del, comp []uint, compBytes int64) {
+	dailyCutoff := now.AddDate(0, 0, -policy.DailyAfterDays)
+	seenDay := map[string]bool{}
+	for i, m := range metas {
+		keep := i < policy.KeepLast ||
+			m.Status == "published" ||
+			m.ArchivedAt.After(dailyCutoff)
+		day := m.ArchivedAt.UTC().Format("2006-01-02")
+		if !keep && !seenDay[day] {
+			keep = true // 當天最新的一個版本
+		}
+		seenDay[day] = true
+
+		if !keep {
+			del = append(del, m.ID)
+			continue
+		}
+		if policy.CompressAfterDays > 0 && !m.Compressed && m.ContentBytes > 0 &&
+			m.ArchivedAt.Before(now.AddDate(0, 0, -policy.CompressAfterDays)) {
+			comp = append(comp, m.ID)
+			compBytes += m.ContentBytes
+		}
+	}
+	return del, comp, compBytes
+}
+
+// compressArchive 把單一 archive 的 content 壓縮到 content_gzip，回傳節省的 bytes。
+func compressArchive(tx *gorm.DB, id uint) (int64, error) {
+	var ar models.ArticleArchive
+	if err := tx.Select("id", "content").First(&ar, id).Error; err != nil {
+		return 0, err
+	}
+	if ar.Content == nil {
+		return 0, nil
+	}
+	gz, err := gzipString(*ar.Content)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Model(&models.ArticleArchive{}).Where("id = ?", id).
+		Updates(map[string]any{"content": nil, "content_gzip": gz}).Error; err != nil {
+		return 0, err
+	}
+	return int64(len(*ar.Content) - len(gz)), nil
+}
+
+// archiveContent 取得 archive 的 content（壓縮過的自動解壓）。
+func archiveContent(ar *models.ArticleArchive) (*string, error) {
+	if ar.ContentGzip == nil {
+		return ar.Content, nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(ar.ContentGzip))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, err
+	}
+	content := string(raw)
+	return &content, nil
+}
+
+func gzipString(s string) ([]byte, error) {
+	var buf bytes.Buffer
+	zw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := zw.Write([]byte(s)); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
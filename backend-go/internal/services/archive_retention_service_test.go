@@ -0,0 +1,78 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPlanRetention(t *testing.T) {
+	now := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+	at := func(daysAgo, hour int) time.Time {
+		d := now.AddDate(0, 0, -daysAgo)
+		return time.Date(d.Year(), d.Month(), d.Day(), hour, 0, 0, 0, time.UTC)
+	}
+	// 新 → 舊：1 天前兩版、40 天前三版（其中一版曾發佈）、41 天前兩版
+	metas := []archiveMeta{
+		{ID: 9, Status: "draft", ArchivedAt: at(1, 10), ContentBytes: 100},
+		{ID: 8, Status: "draft", ArchivedAt: at(1, 9), ContentBytes: 100},
+		{ID: 7, Status: "draft", ArchivedAt: at(40, 18), ContentBytes: 100},
+		{ID: 6, Status: "published", ArchivedAt: at(40, 12), ContentBytes: 100},
+		{ID: 5, Status: "draft", ArchivedAt: at(40, 8), ContentBytes: 100},
+		{ID: 4, Status: "draft", ArchivedAt: at(41, 20), ContentBytes: 100, Compressed: true},
+		{ID: 3, Status: "draft", ArchivedAt: at(41, 7), ContentBytes: 100},
+	}
+
+	cases := []struct {
+		name      string
+		policy    ArchiveRetentionPolicy
+		wantDel   []uint
+		wantComp  []uint
+		wantBytes int64
+	}{
+		{
+			name:    "每日保留最新一版，曾發佈版本不刪",
+			policy:  ArchiveRetentionPolicy{KeepLast: 2, DailyAfterDays: 30},
+			wantDel: []uint{5, 3},
+		},
+		{
+			name:   "KeepLast 涵蓋全部",
+			policy: ArchiveRetentionPolicy{KeepLast: 10, DailyAfterDays: 30},
+		},
+		{
+			name:   "DailyAfterDays 涵蓋全部",
+			policy: ArchiveRetentionPolicy{KeepLast: 0, DailyAfterDays: 60},
+		},
+		{
+			name:    "KeepLast 與 DailyAfterDays 皆為 0：每天只留最新一版",
+			policy:  ArchiveRetentionPolicy{KeepLast: 0, DailyAfterDays: 0},
+			wantDel: []uint{8, 5, 3},
+		},
+		{
+			name:      "保留下來的舊版本壓縮，已壓縮者略過",
+			policy:    ArchiveRetentionPolicy{KeepLast: 2, DailyAfterDays: 30, CompressAfterDays: 7},
+			wantDel:   []uint{5, 3},
+			wantComp:  []uint{7, 6},
+			wantBytes: 200,
+		},
+		{
+			name:    "CompressAfterDays 為 0 不壓縮",
+			policy:  ArchiveRetentionPolicy{KeepLast: 2, DailyAfterDays: 30, CompressAfterDays: 0},
+			wantDel: []uint{5, 3},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			del, comp, compBytes := planRetention(metas, c.policy, now)
+			if !reflect.DeepEqual(del, c.wantDel) {
+				t.Errorf("delete = %v, want %v", del, c.wantDel)
+			}
+			if !reflect.DeepEqual(comp, c.wantComp) {
+				t.Errorf("compress = %v, want %v", comp, c.wantComp)
+			}
+			if compBytes != c.wantBytes {
+				t.Errorf("compBytes = %d, want %d", compBytes, c.wantBytes)
+			}
+		})
+	}
+}
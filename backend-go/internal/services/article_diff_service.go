@@ -0,0 +1,262 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/paulhuang/paulfun-blogger/internal/apierror"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+)
+
+// DiffRefCurrent 代表文章目前版本（非 archive）。
+const DiffRefCurrent = "current"
+
+// articleSnapshot 版本比對用的文章快照（archive 或目前版本皆轉成此結構）。
+type articleSnapshot struct {
+	ref        dto.ArticleVersionRefDto
+	title      string
+	slug       string
+	summary    *string
+	content    *string
+	coverImage *string
+	categoryID *uint
+	tagIDs     []uint
+}
+
+// DiffArticleVersions 比對同一篇文章的兩個版本（archive ID 或 "current"）。
+// 用於發佈前審閱 AI agent 的修改。唯讀。
+func (s *ArticleService) DiffArticleVersions(articleID uint, fromRef, toRef string) (*dto.ArticleDiffDto, error) {
+	from, err := s.loadSnapshot(articleID, fromRef)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.loadSnapshot(articleID, toRef)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := s.diffFields(from, to)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := s.diffTagSets(from.tagIDs, to.tagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ArticleDiffDto{
+		ArticleID: articleID,
+		From:      from.ref,
+		To:        to.ref,
+		Fields:    fields,
+		Tags:      tags,
+		Content:   diffContent(from.content, to.content),
+	}, nil
+}
+
+// loadSnapshot 依 ref 載入快照；archive 必須屬於 articleID。
+func (s *ArticleService) loadSnapshot(articleID uint, ref string) (*articleSnapshot, error) {
+	if ref == "" || ref == DiffRefCurrent {
+		var a models.Article
+		if err := s.db.Preload("Tags").First(&a, articleID).Error; err != nil {
+			return nil, apierror.ErrNotFound
+		}
+		ts := a.CreatedAt
+		if a.UpdatedAt != nil {
+			ts = *a.UpdatedAt
+		}
+		tagIDs := make([]uint, len(a.Tags))
+		for i, t := range a.Tags {
+			tagIDs[i] = t.ID
+		}
+		return &articleSnapshot{
+			ref:        dto.ArticleVersionRefDto{Ref: DiffRefCurrent, Version: a.Version, Status: a.Status, Timestamp: &ts},
+			title:      a.Title,
+			slug:       a.Slug,
+			summary:    a.Summary,
+			content:    a.Content,
+			coverImage: a.CoverImage,
+			categoryID: a.CategoryID,
+			tagIDs:     tagIDs,
+		}, nil
+	}
+
+	archiveID, err := strconv.ParseUint(ref, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: 版本參照須為 archive ID 或 %q", apierror.ErrBadRequest, DiffRefCurrent)
+	}
+	var ar models.ArticleArchive
+	if err := s.db.First(&ar, archiveID).Error; err != nil || ar.ArticleID != articleID {
+		return nil, apierror.ErrNotFound
+	}
+	content, err := archiveContent(&ar)
+	if err != nil {
+		return nil, err
+	}
+	var tagIDs []uint
+	_ = json.Unmarshal([]byte(ar.TagIDs), &tagIDs)
+	archivedAt := ar.ArchivedAt
+	return &articleSnapshot{
+		ref:        dto.ArticleVersionRefDto{Ref: ref, Version: ar.Version, Status: ar.Status, Timestamp: &archivedAt},
+		title:      ar.Title,
+		slug:       ar.Slug,
+		summary:    ar.Summary,
+		content:    content,
+		coverImage: ar.CoverImage,
+		categoryID: ar.CategoryID,
+		tagIDs:     tagIDs,
+	}, nil
+}
+
+// diffFields 比對純量欄位，只回傳有變動者。
+func (s *ArticleService) diffFields(from, to *articleSnapshot) ([]dto.FieldChangeDto, error) {
+	out := []dto.FieldChangeDto{}
+	if from.title != to.title {
+		out = append(out, dto.FieldChangeDto{Field: "title", From: from.title, To: to.title})
+	}
+	if from.slug != to.slug {
+		out = append(out, dto.FieldChangeDto{Field: "slug", From: from.slug, To: to.slug})
+	}
+	if derefStr(from.summary) != derefStr(to.summary) {
+		out = append(out, dto.FieldChangeDto{Field: "summary", From: from.summary, To: to.summary})
+	}
+	if derefStr(from.coverImage) != derefStr(to.coverImage) {
+		out = append(out, dto.FieldChangeDto{Field: "coverImage", From: from.coverImage, To: to.coverImage})
+	}
+	if derefUint(from.categoryID) != derefUint(to.categoryID) {
+		names := map[uint]string{}
+		var cats []models.Category
+		if err := s.db.Where("id IN ?", []uint{derefUint(from.categoryID), derefUint(to.categoryID)}).
+			Find(&cats).Error; err != nil {
+			return nil, err
+		}
+		for _, c := range cats {
+			names[c.ID] = c.Name
+		}
+		ref := func(id *uint) *dto.CategoryRefDto {
+			if id == nil {
+				return nil
+			}
+			return &dto.CategoryRefDto{ID: *id, Name: names[*id]}
+		}
+		out = append(out, dto.FieldChangeDto{Field: "category", From: ref(from.categoryID), To: ref(to.categoryID)})
+	}
+	return out, nil
+}
+
+// diffTagSets 比對標籤集合（依 ID），並補上標籤名稱。
+func (s *ArticleService) diffTagSets(fromIDs, toIDs []uint) (dto.TagSetDiffDto, error) {
+	inFrom := map[uint]bool{}
+	for _, id := range fromIDs {
+		inFrom[id] = true
+	}
+	inTo := map[uint]bool{}
+	for _, id := range toIDs {
+		inTo[id] = true
+	}
+
+	all := make([]uint, 0, len(inFrom)+len(inTo))
+	for id := range inFrom {
+		all = append(all, id)
+	}
+	for id := range inTo {
+		if !inFrom[id] {
+			all = append(all, id)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+
+	byID := map[uint]models.Tag{}
+	if len(all) > 0 {
+		var tags []models.Tag
+		if err := s.db.Where("id IN ?", all).Find(&tags).Error; err != nil {
+			return dto.TagSetDiffDto{}, err
+		}
+		for _, t := range tags {
+			byID[t.ID] = t
+		}
+	}
+
+	out := dto.TagSetDiffDto{Added: []dto.TagDto{}, Removed: []dto.TagDto{}, Unchanged: []dto.TagDto{}}
+	for _, id := range all {
+		t := byID[id]
+		td := dto.TagDto{ID: id, Name: t.Name, Slug: t.Slug}
+		switch {
+		case inFrom[id] && inTo[id]:
+			out.Unchanged = append(out.Unchanged, td)
+		case inTo[id]:
+			out.Added = append(out.Added, td)
+		default:
+			out.Removed = append(out.Removed, td)
+		}
+	}
+	return out, nil
+}
+
+// diffContent 內文逐行比對；相鄰的「刪除行 + 新增行」配對成 replace 並做逐詞比對。
+func diffContent(from, to *string) dto.ContentDiffDto {
+	ops := diffTokens(htmlToLines(from), htmlToLines(to))
+
+	out := dto.ContentDiffDto{Lines: make([]dto.DiffLineDto, 0, len(ops))}
+	for i := 0; i < len(ops); {
+		if ops[i].Op == diffEqual {
+			out.Lines = append(out.Lines, dto.DiffLineDto{Op: diffEqual, Text: ops[i].Text})
+			i++
+			continue
+		}
+
+		// 收集連續的變動區塊：deletes 與 inserts
+		var dels, ins []string
+		for ; i < len(ops) && ops[i].Op != diffEqual; i++ {
+			if ops[i].Op == diffDelete {
+				dels = append(dels, ops[i].Text)
+			} else {
+				ins = append(ins, ops[i].Text)
+			}
+		}
+		paired := len(dels)
+		if len(ins) < paired {
+			paired = len(ins)
+		}
+		for k := 0; k < paired; k++ {
+			out.Lines = append(out.Lines, dto.DiffLineDto{Op: "replace", Segments: diffWords(dels[k], ins[k])})
+		}
+		for _, t := range dels[paired:] {
+			out.Lines = append(out.Lines, dto.DiffLineDto{Op: diffDelete, Text: t})
+		}
+		for _, t := range ins[paired:] {
+			out.Lines = append(out.Lines, dto.DiffLineDto{Op: diffInsert, Text: t})
+		}
+		out.LinesChanged += paired
+		out.LinesRemoved += len(dels) - paired
+		out.LinesAdded += len(ins) - paired
+	}
+	out.Changed = out.LinesAdded+out.LinesRemoved+out.LinesChanged > 0
+	return out
+}
+
+func diffWords(from, to string) []dto.DiffSegmentDto {
+	ops := mergeDiffOps(diffTokens(splitWords(from), splitWords(to)))
+	segs := make([]dto.DiffSegmentDto, len(ops))
+	for i, op := range ops {
+		segs[i] = dto.DiffSegmentDto{Op: op.Op, Text: op.Text}
+	}
+	return segs
+}
+
+func derefStr(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
+
+func derefUint(p *uint) uint {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
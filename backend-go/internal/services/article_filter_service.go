@@ -0,0 +1,94 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/paulhuang/paulfun-blogger/internal/apierror"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"gorm.io/gorm"
+)
+
+// applyTaxonomyFilters 套用分類 / 標籤篩選（GetArticles 使用）。
+//
+//   - 分類：categoryId 或 category（slug）；includeDescendants=true 時以 recursive CTE 含所有子孫分類
+//   - 標籤：tagId、tagIds（csv）、tags（slug csv）合併；tagMode=any 命中任一，all 須全部具備
+//
+// 標籤篩選用子查詢而非 JOIN，避免多標籤時同一篇文章重複出現。
+func (s *ArticleService) applyTaxonomyFilters(query *gorm.DB, q dto.ArticleQueryParams) (*gorm.DB, error) {
+	categoryID := q.CategoryID
+	if q.Category != "" {
+		var cat models.Category
+		if err := s.db.Select("id").Where("slug = ?", q.Category).First(&cat).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("%w: 分類 %q 不存在", apierror.ErrNotFound, q.Category)
+			}
+			return nil, err
+		}
+		categoryID = &cat.ID
+	}
+	if categoryID != nil {
+		if q.IncludeDescendants {
+			// UNION（非 UNION ALL）去重，資料異常成環時也會收斂
+			query = query.Where(`category_id IN (
+				WITH RECURSIVE subtree AS (
+					SELECT id FROM categories WHERE id = ?
+					UNION
+					SELECT c.id FROM categories c JOIN subtree ON c.parent_id = subtree.id
+				)
+				SELECT id FROM subtree)`, *categoryID)
+		} else {
+			query = query.Where("category_id = ?", *categoryID)
+		}
+	}
+
+	tagIDs := parseCSVUints(q.TagIDs)
+	if q.TagID != nil {
+		tagIDs = append(tagIDs, *q.TagID)
+	}
+	if slugs := parseCSVStrings(q.Tags); len(slugs) > 0 {
+		var tags []models.Tag
+		if err := s.db.Select("id", "slug").Where("slug IN ?", slugs).Find(&tags).Error; err != nil {
+			return nil, err
+		}
+		found := map[string]bool{}
+		for _, t := range tags {
+			found[t.Slug] = true
+			tagIDs = append(tagIDs, t.ID)
+		}
+		for _, slug := range slugs {
+			if !found[slug] {
+				return nil, fmt.Errorf("%w: 標籤 %q 不存在", apierror.ErrNotFound, slug)
+			}
+		}
+	}
+	tagIDs = uniqueUints(tagIDs)
+	if len(tagIDs) == 0 {
+		return query, nil
+	}
+
+	switch strings.ToLower(q.TagMode) {
+	case "", "any":
+		query = query.Where("articles.id IN (SELECT article_id FROM article_tags WHERE tag_id IN ?)", tagIDs)
+	case "all":
+		query = query.Where(`articles.id IN (
+			SELECT article_id FROM article_tags WHERE tag_id IN ?
+			GROUP BY article_id HAVING COUNT(DISTINCT tag_id) = ?)`, tagIDs, len(tagIDs))
+	default:
+		return nil, fmt.Errorf("%w: tagMode 僅接受 any / all", apierror.ErrBadRequest)
+	}
+	return query, nil
+}
+
+// parseCSVStrings 解析 csv 字串（去空白、略過空值）。
+func parseCSVStrings(raw string) []string {
+	var out []string
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/paulhuang/paulfun-blogger/internal/apierror"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/graph"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+)
+
+const (
+	graphMaxOrphans    = 100
+	graphTopComponents = 10
+)
+
+// GraphExport 匯出結果。
+type GraphExport struct {
+	Body        []byte
+	ContentType string
+	Filename    string
+}
+
+// ExportGraph 匯出文章知識圖譜（json | graphml | dot）。
+func (s *ArticleService) ExportGraph(q dto.GraphQueryParams) (*GraphExport, error) {
+	g, err := s.buildGraph(q)
+	if err != nil {
+		return nil, err
+	}
+	if q.HideOrphans {
+		g = withoutOrphans(g)
+	}
+
+	switch q.Format {
+	case "", "json":
+		body, err := graph.JSON(g)
+		if err != nil {
+			return nil, err
+		}
+		return &GraphExport{Body: body, ContentType: "application/json; charset=utf-8", Filename: "article-graph.json"}, nil
+	case "graphml":
+		body, err := graph.GraphML(g)
+		if err != nil {
+			return nil, err
+		}
+		return &GraphExport{Body: body, ContentType: "application/graphml+xml; charset=utf-8", Filename: "article-graph.graphml"}, nil
+	case "dot":
+		return &GraphExport{Body: graph.DOT(g), ContentType: "text/vnd.graphviz; charset=utf-8", Filename: "article-graph.dot"}, nil
+	default:
+		return nil, fmt.Errorf("%w: format 僅接受 json / graphml / dot", apierror.ErrBadRequest)
+	}
+}
+
+// GetGraphStats 圖統計：孤立文章與最大連通分量（篩選條件同匯出，hideOrphans 不影響統計）。
+func (s *ArticleService) GetGraphStats(q dto.GraphQueryParams) (*dto.GraphStatsDto, error) {
+	g, err := s.buildGraph(q)
+	if err != nil {
+		return nil, err
+	}
+	st := g.ComputeStats(graphTopComponents)
+
+	nodes := make(map[uint]graph.Node, len(g.Nodes))
+	for _, n := range g.Nodes {
+		nodes[n.ID] = n
+	}
+	refs := func(ids []uint) []dto.GraphNodeRefDto {
+		out := make([]dto.GraphNodeRefDto, len(ids))
+		for i, id := range ids {
+			n := nodes[id]
+			out[i] = dto.GraphNodeRefDto{ID: n.ID, Title: n.Title, Status: n.Status}
+		}
+		return out
+	}
+
+	result := &dto.GraphStatsDto{
+		NodeCount:      st.NodeCount,
+		EdgeCount:      st.EdgeCount,
+		EdgesByType:    st.EdgesByType,
+		OrphanCount:    len(st.Orphans),
+		ComponentCount: st.ComponentCount,
+		Largest:        make([]dto.GraphComponentDto, len(st.Largest)),
+	}
+	orphans := st.Orphans
+	if len(orphans) > graphMaxOrphans {
+		orphans = orphans[:graphMaxOrphans]
+	}
+	result.Orphans = refs(orphans)
+	for i, c := range st.Largest {
+		result.Largest[i] = dto.GraphComponentDto{Size: c.Size, Articles: refs(c.NodeIDs)}
+	}
+	return result, nil
+}
+
+// buildGraph 依篩選條件載入節點（不含垃圾桶）與兩端都在結果中的邊。
+// related 邊取自 ArticleLink；series 邊連接同一系列中（篩選後）相鄰的兩篇，舊 → 新。
+func (s *ArticleService) buildGraph(q dto.GraphQueryParams) (*graph.Graph, error) {
+	switch q.Relation {
+	case "", models.LinkRelationRelated, models.LinkRelationSeries:
+	default:
+		return nil, fmt.Errorf("%w: relation 僅接受 related / series", apierror.ErrBadRequest)
+	}
+
+	query := s.db.Model(&models.Article{}).
+		Select("id", "title", "slug", "status", "category_id").
+		Preload("Category").
+		Preload("Tags")
+	if q.Status != "" {
+		query = query.Where("status = ?", q.Status)
+	}
+	query, err := s.applyTaxonomyFilters(query, dto.ArticleQueryParams{
+		CategoryID:         q.CategoryID,
+		Category:           q.Category,
+		IncludeDescendants: q.IncludeDescendants,
+		TagIDs:             q.TagIDs,
+		Tags:               q.Tags,
+		TagMode:            q.TagMode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var articles []models.Article
+	if err := query.Order("id").Find(&articles).Error; err != nil {
+		return nil, err
+	}
+
+	g := &graph.Graph{Nodes: make([]graph.Node, len(articles))}
+	ids := make([]uint, len(articles))
+	included := make(map[uint]bool, len(articles))
+	for i, a := range articles {
+		n := graph.Node{ID: a.ID, Title: a.Title, Slug: a.Slug, Status: a.Status}
+		if a.Category != nil {
+			n.Category = a.Category.Name
+		}
+		for _, t := range a.Tags {
+			n.Tags = append(n.Tags, t.Name)
+		}
+		g.Nodes[i] = n
+		ids[i] = a.ID
+		included[a.ID] = true
+	}
+	if len(ids) == 0 {
+		return g, nil
+	}
+
+	if q.Relation != models.LinkRelationSeries {
+		var links []models.ArticleLink
+		if err := s.db.Where("relation = ? AND from_article_id IN ? AND to_article_id IN ?",
+			models.LinkRelationRelated, ids, ids).Order("id").Find(&links).Error; err != nil {
+			return nil, err
+		}
+		for _, l := range links {
+			e := graph.Edge{From: l.FromArticleID, To: l.ToArticleID, Relation: l.Relation}
+			if l.Note != nil {
+				e.Note = *l.Note
+			}
+			g.Edges = append(g.Edges, e)
+		}
+	}
+
+	if q.Relation != models.LinkRelationRelated {
+		var members []models.SeriesArticle
+		if err := s.db.Where("article_id IN ?", ids).
+			Order("series_id, position, article_id").Find(&members).Error; err != nil {
+			return nil, err
+		}
+		seriesTitles := map[uint]string{}
+		var seriesIDs []uint
+		for _, m := range members {
+			if _, ok := seriesTitles[m.SeriesID]; !ok {
+				seriesTitles[m.SeriesID] = ""
+				seriesIDs = append(seriesIDs, m.SeriesID)
+			}
+		}
+		var series []models.Series
+		if err := s.db.Select("id", "title").Where("id IN ?", seriesIDs).Find(&series).Error; err != nil {
+			return nil, err
+		}
+		for _, sr := range series {
+			seriesTitles[sr.ID] = sr.Title
+		}
+		for i := 1; i < len(members); i++ {
+			prev, cur := members[i-1], members[i]
+			if prev.SeriesID != cur.SeriesID || !included[prev.ArticleID] || !included[cur.ArticleID] {
+				continue
+			}
+			e := graph.Edge{From: prev.ArticleID, To: cur.ArticleID, Relation: models.LinkRelationSeries, Series: seriesTitles[cur.SeriesID]}
+			if cur.Note != nil {
+				e.Note = *cur.Note
+			}
+			g.Edges = append(g.Edges, e)
+		}
+	}
+	return g, nil
+}
+
+// withoutOrphans 移除沒有任何邊的節點。
+func withoutOrphans(g *graph.Graph) *graph.Graph {
+	linked := make(map[uint]bool)
+	for _, e := range g.Edges {
+		linked[e.From] = true
+		linked[e.To] = true
+	}
+	out := &graph.Graph{Edges: g.Edges}
+	for _, n := range g.Nodes {
+		if linked[n.ID] {
+			out.Nodes = append(out.Nodes, n)
+		}
+	}
+	return out
+}
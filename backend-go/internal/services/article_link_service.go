@@ -1,8 +1,10 @@
 package services
 
 import (
+	"errors"
 	"fmt"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/paulhuang/paulfun-blogger/internal/apierror"
@@ -11,22 +13,26 @@ import (
 	"gorm.io/gorm"
 )
 
-// seriesWalkLimit series 鏈走訪深度上限（防資料異常成環時無限迴圈）。
-const seriesWalkLimit = 20
-
-// ArticleLinkService 文章知識串連。
+// ArticleLinkService 文章知識串連（手動串連 + 內容相似度推薦）。
 type ArticleLinkService struct {
 	db *gorm.DB
+
+	suggestMu    sync.Mutex
+	suggestIndex *suggestionIndex // nil = 需重建
 }
 
 func NewArticleLinkService(db *gorm.DB) *ArticleLinkService {
 	return &ArticleLinkService{db: db}
 }
 
-// CreateLink 建立串連。Pre: 兩端存在、非 self-link、series 不得成環。
+// CreateLink 建立 related 串連。Pre: 兩端存在、非 self-link。
+// 系列改由 SeriesService 管理，relation = series 一律拒絕。
 func (s *ArticleLinkService) CreateLink(fromID uint, req *dto.CreateArticleLinkRequest) (*dto.ArticleLinkDto, error) {
-	if req.Relation != models.LinkRelationRelated && req.Relation != models.LinkRelationSeries {
-		return nil, fmt.Errorf("%w: relation 僅接受 related / series", apierror.ErrBadRequest)
+	if req.Relation == models.LinkRelationSeries {
+		return nil, fmt.Errorf("%w: 系列請改用 /api/admin/series 管理", apierror.ErrBadRequest)
+	}
+	if req.Relation != models.LinkRelationRelated {
+		return nil, fmt.Errorf("%w: relation 僅接受 related", apierror.ErrBadRequest)
 	}
 	if fromID == req.ToArticleID {
 		return nil, fmt.Errorf("%w: 不可將文章串連到自己", apierror.ErrBadRequest)
@@ -40,19 +46,6 @@ func (s *ArticleLinkService) CreateLink(fromID uint, req *dto.CreateArticleLinkR
 		return nil, apierror.ErrNotFound
 	}
 
-	// series 防環：沿「from 承接 to」方向從 to 出發往舊走，不得回到 from
-	if req.Relation == models.LinkRelationSeries {
-		older, err := s.walkSeries(req.ToArticleID, false)
-		if err != nil {
-			return nil, err
-		}
-		for _, id := range older {
-			if id == fromID {
-				return nil, fmt.Errorf("%w: 此串連會使 series 成環", apierror.ErrBadRequest)
-			}
-		}
-	}
-
 	link := models.ArticleLink{
 		FromArticleID: fromID,
 		ToArticleID:   req.ToArticleID,
@@ -87,9 +80,10 @@ func (s *ArticleLinkService) GetLinks(articleID uint) ([]dto.ArticleLinkDto, err
 
 	out := make([]dto.ArticleLinkDto, 0, len(links))
 	for _, l := range links {
-		if l.FromArticleID == articleID {
+		// 另一端在垃圾桶時 Preload 取不到（ID 為 0）：先隱藏，還原後自然重新出現
+		if l.FromArticleID == articleID && l.ToArticle.ID != 0 {
 			out = append(out, mapLinkDto(l, l.ToArticle, "outgoing"))
-		} else {
+		} else if l.ToArticleID == articleID && l.FromArticle.ID != 0 {
 			out = append(out, mapLinkDto(l, l.FromArticle, "incoming"))
 		}
 	}
@@ -108,33 +102,18 @@ func (s *ArticleLinkService) DeleteLink(articleID, linkID uint) error {
 	return s.db.Delete(&link).Error
 }
 
-// GetRelated 公開端：完整 series 鏈 + related 清單，僅含已發佈文章。
+// GetRelated 公開端：所屬系列 + related 清單 + 內容相似度推薦，僅含已發佈文章。
 func (s *ArticleLinkService) GetRelated(articleID uint) (*dto.RelatedArticlesDto, error) {
 	var article models.Article
 	if err := s.db.First(&article, articleID).Error; err != nil {
 		return nil, apierror.ErrNotFound
 	}
 
-	// series：往舊（outgoing）與往新（incoming）各走一遍，重建完整鏈
-	olderIDs, err := s.walkSeries(articleID, false)
-	if err != nil {
-		return nil, err
-	}
-	newerIDs, err := s.walkSeries(articleID, true)
+	series, seriesRef, seriesIDs, err := s.loadSeries(articleID)
 	if err != nil {
 		return nil, err
 	}
 
-	seriesIDs := append(append(olderIDs, articleID), newerIDs...)
-	series, err := s.loadSeriesItems(seriesIDs, articleID)
-	if err != nil {
-		return nil, err
-	}
-	// 全系列只剩自己一篇 = 沒有可顯示的系列
-	if len(series) <= 1 {
-		series = []dto.SeriesItemDto{}
-	}
-
 	// related：雙向收集去重
 	var links []models.ArticleLink
 	if err := s.db.Where("relation = ? AND (from_article_id = ? OR to_article_id = ?)",
@@ -160,88 +139,64 @@ func (s *ArticleLinkService) GetRelated(articleID uint) (*dto.RelatedArticlesDto
 		return nil, err
 	}
 
-	return &dto.RelatedArticlesDto{Series: series, Related: related}, nil
-}
-
-// walkSeries 沿 series 鏈走訪。newer=false 往舊走（沿 outgoing from→to）；
-// newer=true 往新走（沿 incoming to←from）。回傳不含起點，按走訪順序。
-func (s *ArticleLinkService) walkSeries(start uint, newer bool) ([]uint, error) {
-	var out []uint
-	visited := map[uint]bool{start: true}
-	current := start
-	for i := 0; i < seriesWalkLimit; i++ {
-		var link models.ArticleLink
-		var err error
-		if newer {
-			err = s.db.Where("relation = ? AND to_article_id = ?", models.LinkRelationSeries, current).First(&link).Error
-		} else {
-			err = s.db.Where("relation = ? AND from_article_id = ?", models.LinkRelationSeries, current).First(&link).Error
-		}
-		if err != nil {
-			break // 鏈到底
-		}
-		next := link.ToArticleID
-		if newer {
-			next = link.FromArticleID
-		}
-		if visited[next] {
-			break // 資料異常成環，停止
-		}
-		visited[next] = true
-		out = append(out, next)
-		current = next
-	}
-	if !newer {
-		// 往舊走的結果是「近 → 遠」，反轉成「舊 → 新」方便串接
-		for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
-			out[i], out[j] = out[j], out[i]
-		}
+	// suggested：排除已在 series / related 出現的文章
+	listed := map[uint]bool{}
+	for _, id := range seriesIDs {
+		listed[id] = true
 	}
-	return out, nil
-}
-
-// loadSeriesItems 載入系列文章（保持傳入順序），濾掉未發佈；current 一律保留。
-func (s *ArticleLinkService) loadSeriesItems(ids []uint, currentID uint) ([]dto.SeriesItemDto, error) {
-	if len(ids) == 0 {
-		return []dto.SeriesItemDto{}, nil
+	for _, id := range relatedIDs {
+		listed[id] = true
 	}
-	var articles []models.Article
-	if err := s.db.Where("id IN ?", ids).Find(&articles).Error; err != nil {
+	suggested, err := s.suggest(&article, publicSuggestLimit, func(id uint) bool { return listed[id] })
+	if err != nil {
 		return nil, err
 	}
-	byID := map[uint]models.Article{}
-	for _, a := range articles {
-		byID[a.ID] = a
+
+	return &dto.RelatedArticlesDto{Series: series, SeriesInfo: seriesRef, Related: related, Suggested: suggested}, nil
+}
+
+// loadSeries 文章所屬系列（依順序，濾掉未發佈；當前文章一律保留）。
+// 不屬於任何系列、或系列中只剩自己一篇可顯示時回空清單與 nil。
+// 第三個回傳值為系列全部成員 ID（含未發佈），供 suggested 排除。
+func (s *ArticleLinkService) loadSeries(currentID uint) ([]dto.SeriesItemDto, *dto.SeriesRefDto, []uint, error) {
+	var membership models.SeriesArticle
+	if err := s.db.Where("article_id = ?", currentID).First(&membership).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return []dto.SeriesItemDto{}, nil, nil, nil
+		}
+		return nil, nil, nil, err
 	}
-	// series link 的 note 標在 from（較新那篇承接時的說明）
-	var links []models.ArticleLink
-	if err := s.db.Where("relation = ? AND from_article_id IN ?", models.LinkRelationSeries, ids).Find(&links).Error; err != nil {
-		return nil, err
+	var series models.Series
+	if err := s.db.First(&series, membership.SeriesID).Error; err != nil {
+		return nil, nil, nil, err
 	}
-	noteByFrom := map[uint]*string{}
-	for _, l := range links {
-		noteByFrom[l.FromArticleID] = l.Note
+	members, err := seriesMembers(s.db, series.ID, false)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
-	out := make([]dto.SeriesItemDto, 0, len(ids))
-	for _, id := range ids {
-		a, ok := byID[id]
-		if !ok {
-			continue
-		}
-		if a.Status != "published" && a.ID != currentID {
+	now := time.Now().UTC()
+	ids := make([]uint, 0, len(members))
+	out := make([]dto.SeriesItemDto, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.ArticleID)
+		if !isPubliclyVisible(m.Article, now) && m.ArticleID != currentID {
 			continue // 未發佈不外洩；當前文章本身照常顯示
 		}
 		out = append(out, dto.SeriesItemDto{
-			ID:          a.ID,
-			Title:       a.Title,
-			Slug:        a.Slug,
-			PublishedAt: fmtTimePtr(a.PublishedAt),
-			Note:        noteByFrom[a.ID],
-			IsCurrent:   a.ID == currentID,
+			ID:          m.Article.ID,
+			Title:       m.Article.Title,
+			Slug:        m.Article.Slug,
+			PublishedAt: fmtTimePtr(m.Article.PublishedAt),
+			Note:        m.Note,
+			IsCurrent:   m.ArticleID == currentID,
 		})
 	}
-	return out, nil
+	// 全系列只剩自己一篇 = 沒有可顯示的系列
+	if len(out) <= 1 {
+		return []dto.SeriesItemDto{}, nil, ids, nil
+	}
+	return out, &dto.SeriesRefDto{ID: series.ID, Title: series.Title, Slug: series.Slug}, ids, nil
 }
 
 // loadPublishedItems 載入 related 文章（僅已發佈），按 publishedAt 新 → 舊。
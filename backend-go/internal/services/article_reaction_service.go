@@ -1,44 +1,398 @@
 package services
 
 import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/paulhuang/paulfun-blogger/internal/apierror"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/fingerprint"
 	"github.com/paulhuang/paulfun-blogger/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-// LikeArticle 按讚（+1）。僅限已發佈文章；回傳最新計數。
-// 匿名制：重複點擊由前端 localStorage 防範，後端以 rate limit 擋濫用。
-func (s *ArticleService) LikeArticle(id uint) (int, error) {
-	return s.adjustLike(id, +1)
+// LikeArticle 按讚（冪等）。僅限已發佈文章；同一讀者重複按讚不重複計數。
+//
+// 讀者以匿名 cookie 識別。cookie 未滿一個 salt 週期（含本次新發）時另以 IP+UA 雜湊比對：
+// 新 cookie 隨時可以拿到（任何 GET 都會發），不能單靠它去重；已建立的 cookie 則不比對，
+// 同一 NAT 後使用相同瀏覽器的不同讀者（例如電信 CGNAT）各自計數。
+func (s *ArticleService) LikeArticle(id uint, reader fingerprint.Reader) (dto.LikeStatusDto, error) {
+	if err := s.EnsureLikeable(id); err != nil {
+		return dto.LikeStatusDto{}, err
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		dup, err := networkDuplicate(tx, &models.ArticleLike{}, id, reader, nil)
+		if err != nil || dup {
+			return err
+		}
+
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.ArticleLike{
+			ArticleID:   id,
+			ReaderID:    reader.ID,
+			NetworkHash: reader.NetworkHash,
+		})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		return tx.Model(&models.Article{}).Where("id = ?", id).
+			UpdateColumn("like_count", gorm.Expr("like_count + 1")).Error
+	})
+	if err != nil {
+		return dto.LikeStatusDto{}, err
+	}
+	return s.LikeStatus(id, reader)
 }
 
-// UnlikeArticle 收回讚（-1），計數不低於 0。
-func (s *ArticleService) UnlikeArticle(id uint) (int, error) {
-	return s.adjustLike(id, -1)
+// UnlikeArticle 收回讚（冪等）。沒帶 cookie 時以 IP+UA 雜湊找回同一來源的讚。
+func (s *ArticleService) UnlikeArticle(id uint, reader fingerprint.Reader) (dto.LikeStatusDto, error) {
+	if err := s.EnsureLikeable(id); err != nil {
+		return dto.LikeStatusDto{}, err
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		res := readerLikes(tx.Where("article_id = ?", id), reader).Delete(&models.ArticleLike{})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		return tx.Model(&models.Article{}).Where("id = ?", id).
+			UpdateColumn("like_count", gorm.Expr("GREATEST(like_count - ?, 0)", res.RowsAffected)).Error
+	})
+	if err != nil {
+		return dto.LikeStatusDto{}, err
+	}
+	return s.LikeStatus(id, reader)
 }
 
-func (s *ArticleService) adjustLike(id uint, delta int) (int, error) {
+// LikeStatus 取得讚數與讀者是否已按讚（不變更計數）。
+func (s *ArticleService) LikeStatus(id uint, reader fingerprint.Reader) (dto.LikeStatusDto, error) {
 	var article models.Article
 	if err := s.db.Select("id", "status", "like_count").First(&article, id).Error; err != nil {
-		return 0, apierror.ErrNotFound
+		return dto.LikeStatusDto{}, apierror.ErrNotFound
 	}
 	if article.Status != "published" {
-		return 0, apierror.ErrNotFound // 草稿不對外洩漏存在性
+		return dto.LikeStatusDto{}, apierror.ErrNotFound
+	}
+
+	var n int64
+	if err := readerLikes(s.db.Model(&models.ArticleLike{}).Where("article_id = ?", id), reader).
+		Count(&n).Error; err != nil {
+		return dto.LikeStatusDto{}, err
+	}
+	return dto.LikeStatusDto{LikeCount: article.LikeCount, Liked: n > 0}, nil
+}
+
+// ReconcileLikeCounts 以 like_base + article_likes 筆數重算 like_count，回傳被校正的文章數。
+// 計數在按讚時即時增減，此處只修正並發或中斷造成的偏差（背景 job 定期執行）。
+func (s *ArticleService) ReconcileLikeCounts() (int64, error) {
+	res := s.db.Exec(`
+		UPDATE articles a
+		SET like_count = a.like_base + COALESCE(l.cnt, 0)
+		FROM articles x
+		LEFT JOIN (SELECT article_id, COUNT(*) AS cnt FROM article_likes GROUP BY article_id) l
+			ON l.article_id = x.id
+		WHERE a.id = x.id AND a.like_count <> a.like_base + COALESCE(l.cnt, 0)`)
+	return res.RowsAffected, res.Error
+}
+
+// EnsureLikeable 文章存在且已發佈才可按讚 / 回應，否則回 ErrNotFound。
+// handler 在 spam 檢查前先呼叫，不存在的文章 ID 不會寫入判定紀錄。
+func (s *ArticleService) EnsureLikeable(id uint) error {
+	var article models.Article
+	if err := s.db.Select("id", "status").First(&article, id).Error; err != nil {
+		return apierror.ErrNotFound
+	}
+	if article.Status != "published" {
+		return apierror.ErrNotFound // 草稿不對外洩漏存在性
+	}
+	return nil
+}
+
+// networkDuplicate 在 transaction 內鎖住文章（FOR UPDATE，讓同篇文章的讚 / 回應依序寫入），
+// 讀者 cookie 尚未 Established 時再檢查同一 IP+UA 雜湊是否已有紀錄。
+// model 為 ArticleLike 或 ArticleReaction；scope 可再加條件（例如限定 reaction）。
+func networkDuplicate(tx *gorm.DB, model any, id uint, reader fingerprint.Reader, scope func(*gorm.DB) *gorm.DB) (bool, error) {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").First(&models.Article{}, id).Error; err != nil {
+		return false, err
+	}
+	if reader.Established {
+		return false, nil
+	}
+	q := tx.Model(model).Where("article_id = ? AND network_hash = ?", id, reader.NetworkHash)
+	if scope != nil {
+		q = scope(q)
+	}
+	var n int64
+	if err := q.Count(&n).Error; err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// readerLikes 限定為該讀者的讚 / 回應：有 cookie 以 reader_id，沒有時以 network_hash。
+func readerLikes(q *gorm.DB, reader fingerprint.Reader) *gorm.DB {
+	if reader.Anonymous() {
+		return q.Where("network_hash = ?", reader.NetworkHash)
+	}
+	return q.Where("reader_id = ?", reader.ID)
+}
+
+// ── 表情回應 ─────────────────────────────────────────────────────────────
+
+// ReactionType 一種可用的表情回應。
+type ReactionType struct {
+	Key   string // URL 與資料庫使用，例如 thumbsup
+	Emoji string // 前台顯示，例如 👍
+}
+
+var reactionKeyRe = regexp.MustCompile(`^[a-z0-9_-]{1,20}$`)
+
+// ParseReactionTypes 解析設定字串 "key:emoji,key:emoji"（順序即顯示順序）。
+func ParseReactionTypes(spec string) ([]ReactionType, error) {
+	var types []ReactionType
+	seen := map[string]bool{}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		key, emoji, ok := strings.Cut(item, ":")
+		key, emoji = strings.TrimSpace(key), strings.TrimSpace(emoji)
+		if !ok || !reactionKeyRe.MatchString(key) || emoji == "" {
+			return nil, fmt.Errorf("無效的 reaction 設定 %q（格式 key:emoji，key 限小寫英數 _ -）", item)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("reaction key %q 重複", key)
+		}
+		seen[key] = true
+		types = append(types, ReactionType{Key: key, Emoji: emoji})
+	}
+	return types, nil
+}
+
+// SetReactionTypes 設定可用的表情回應；只在啟動時呼叫。
+func (s *ArticleService) SetReactionTypes(types []ReactionType) {
+	s.reactionTypes = types
+}
+
+func (s *ArticleService) reactionType(key string) (ReactionType, error) {
+	for _, t := range s.reactionTypes {
+		if t.Key == key {
+			return t, nil
+		}
+	}
+	return ReactionType{}, fmt.Errorf("%w: 不支援的表情回應 %q", apierror.ErrBadRequest, key)
+}
+
+// AddReaction 給文章表情回應（冪等）；去重規則同 LikeArticle（每種表情各自以 cookie 與 IP+UA 雜湊去重）。
+func (s *ArticleService) AddReaction(id uint, key string, reader fingerprint.Reader) (dto.ReactionStatusDto, error) {
+	if _, err := s.reactionType(key); err != nil {
+		return dto.ReactionStatusDto{}, err
 	}
+	if err := s.EnsureLikeable(id); err != nil {
+		return dto.ReactionStatusDto{}, err
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		dup, err := networkDuplicate(tx, &models.ArticleReaction{}, id, reader, func(q *gorm.DB) *gorm.DB {
+			return q.Where("reaction = ?", key)
+		})
+		if err != nil || dup {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.ArticleReaction{
+			ArticleID:   id,
+			ReaderID:    reader.ID,
+			Reaction:    key,
+			NetworkHash: reader.NetworkHash,
+		}).Error
+	})
+	if err != nil {
+		return dto.ReactionStatusDto{}, err
+	}
+	return s.ReactionStatus(id, reader)
+}
+
+// RemoveReaction 收回表情回應（冪等）。
+func (s *ArticleService) RemoveReaction(id uint, key string, reader fingerprint.Reader) (dto.ReactionStatusDto, error) {
+	if _, err := s.reactionType(key); err != nil {
+		return dto.ReactionStatusDto{}, err
+	}
+	if err := s.EnsureLikeable(id); err != nil {
+		return dto.ReactionStatusDto{}, err
+	}
+
+	if err := readerLikes(s.db.Where("article_id = ? AND reaction = ?", id, key), reader).
+		Delete(&models.ArticleReaction{}).Error; err != nil {
+		return dto.ReactionStatusDto{}, err
+	}
+	return s.ReactionStatus(id, reader)
+}
+
+// ReactionStatus 取得文章各表情回應數與讀者已給的回應。
+func (s *ArticleService) ReactionStatus(id uint, reader fingerprint.Reader) (dto.ReactionStatusDto, error) {
+	if err := s.EnsureLikeable(id); err != nil {
+		return dto.ReactionStatusDto{}, err
+	}
+	counts, err := s.reactionCounts(id)
+	if err != nil {
+		return dto.ReactionStatusDto{}, err
+	}
+
+	var mine []string
+	if err := readerLikes(s.db.Model(&models.ArticleReaction{}).Where("article_id = ?", id), reader).
+		Order("reaction").Pluck("reaction", &mine).Error; err != nil {
+		return dto.ReactionStatusDto{}, err
+	}
+	out := dto.ReactionStatusDto{Reactions: counts, Mine: []string{}}
+	for _, key := range mine {
+		if _, err := s.reactionType(key); err == nil {
+			out.Mine = append(out.Mine, key)
+		}
+	}
+	return out, nil
+}
+
+// reactionCounts 依設定順序回傳各表情回應數（含 0；已從設定移除的表情不列出）。
+func (s *ArticleService) reactionCounts(id uint) ([]dto.ReactionCountDto, error) {
+	type row struct {
+		Reaction string
+		Cnt      int
+	}
+	var rows []row
+	if err := s.db.Model(&models.ArticleReaction{}).
+		Select("reaction, COUNT(*) AS cnt").
+		Where("article_id = ?", id).
+		Group("reaction").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	byKey := map[string]int{}
+	for _, r := range rows {
+		byKey[r.Reaction] = r.Cnt
+	}
+	return s.reactionCountList(byKey), nil
+}
 
-	// 原子更新；-1 時以 GREATEST 保證不低於 0
-	expr := gorm.Expr("like_count + ?", delta)
-	if delta < 0 {
-		expr = gorm.Expr("GREATEST(like_count + ?, 0)", delta)
+func (s *ArticleService) reactionCountList(byKey map[string]int) []dto.ReactionCountDto {
+	out := make([]dto.ReactionCountDto, len(s.reactionTypes))
+	for i, t := range s.reactionTypes {
+		out[i] = dto.ReactionCountDto{Type: t.Key, Emoji: t.Emoji, Count: byKey[t.Key]}
 	}
-	if err := s.db.Model(&models.Article{}).Where("id = ?", id).
-		UpdateColumn("like_count", expr).Error; err != nil {
-		return 0, err
+	return out
+}
+
+// attachReactions 為單篇文章 DTO 補上表情回應數；查詢失敗只記 log，不影響文章本身。
+func (s *ArticleService) attachReactions(d *dto.ArticleDto) {
+	counts, err := s.reactionCounts(d.ID)
+	if err != nil {
+		log.Printf("[reaction] 查詢回應數失敗 article_id=%d: %v", d.ID, err)
+		return
 	}
+	d.Reactions = counts
+}
 
-	var updated models.Article
-	if err := s.db.Select("like_count").First(&updated, id).Error; err != nil {
-		return 0, err
+// GetReactionStats 後台回應統計：各表情總數，以及依總數（或指定表情）排序的文章排行。
+func (s *ArticleService) GetReactionStats(q dto.ReactionStatsQueryParams) (dto.ReactionStatsDto, error) {
+	if q.Type != "" {
+		if _, err := s.reactionType(q.Type); err != nil {
+			return dto.ReactionStatsDto{}, err
+		}
+	}
+	keys := make([]string, len(s.reactionTypes))
+	for i, t := range s.reactionTypes {
+		keys[i] = t.Key
+	}
+
+	base := s.db.Model(&models.ArticleReaction{}).Where("reaction IN ?", keys)
+	if q.Days > 0 {
+		base = base.Where("created_at >= ?", time.Now().UTC().AddDate(0, 0, -q.Days))
+	}
+
+	type pairRow struct {
+		ArticleID uint
+		Reaction  string
+		Cnt       int
+	}
+	var pairs []pairRow
+	if err := base.Session(&gorm.Session{}).
+		Select("article_id, reaction, COUNT(*) AS cnt").
+		Group("article_id, reaction").
+		Scan(&pairs).Error; err != nil {
+		return dto.ReactionStatsDto{}, err
+	}
+
+	totals := map[string]int{}
+	perArticle := map[uint]map[string]int{}
+	for _, p := range pairs {
+		totals[p.Reaction] += p.Cnt
+		if perArticle[p.ArticleID] == nil {
+			perArticle[p.ArticleID] = map[string]int{}
+		}
+		perArticle[p.ArticleID][p.Reaction] += p.Cnt
+	}
+
+	ids := make([]uint, 0, len(perArticle))
+	for id := range perArticle {
+		ids = append(ids, id)
+	}
+	score := func(id uint) int {
+		if q.Type != "" {
+			return perArticle[id][q.Type]
+		}
+		n := 0
+		for _, c := range perArticle[id] {
+			n += c
+		}
+		return n
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if si, sj := score(ids[i]), score(ids[j]); si != sj {
+			return si > sj
+		}
+		return ids[i] < ids[j]
+	})
+	if limit := q.GetLimit(); len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	articles := map[uint]models.Article{}
+	if len(ids) > 0 {
+		var list []models.Article
+		if err := s.db.Select("id", "title", "slug").Where("id IN ?", ids).Find(&list).Error; err != nil {
+			return dto.ReactionStatsDto{}, err
+		}
+		for _, a := range list {
+			articles[a.ID] = a
+		}
+	}
+
+	out := dto.ReactionStatsDto{
+		Totals:   s.reactionCountList(totals),
+		Articles: make([]dto.ArticleReactionStatsDto, 0, len(ids)),
+	}
+	for _, id := range ids {
+		a, ok := articles[id]
+		if !ok {
+			continue // 已在垃圾桶
+		}
+		total := 0
+		for _, c := range perArticle[id] {
+			total += c
+		}
+		out.Articles = append(out.Articles, dto.ArticleReactionStatsDto{
+			ArticleID: id,
+			Title:     a.Title,
+			Slug:      a.Slug,
+			Total:     total,
+			Reactions: s.reactionCountList(perArticle[id]),
+		})
 	}
-	return updated.LikeCount, nil
+	return out, nil
 }
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/paulhuang/paulfun-blogger/internal/fingerprint"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newLikeTestDB 連到 TEST_DATABASE_URL 並在獨立 schema 建立按讚 / 回應所需的資料表，
+// 回傳一篇已發佈文章的 ID。未設定 TEST_DATABASE_URL 時略過測試。
+func newLikeTestDB(t *testing.T) (*gorm.DB, uint) {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL 未設定")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("連線失敗: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1) // search_path 是連線層級設定
+
+	schema := fmt.Sprintf("test_likes_%d", time.Now().UnixNano())
+	if err := db.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		db.Exec("DROP SCHEMA " + schema + " CASCADE")
+		sqlDB.Close()
+	})
+	if err := db.Exec("SET search_path TO " + schema).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&models.User{}, &models.Category{}, &models.Tag{}, &models.Article{},
+		&models.ArticleLike{}, &models.ArticleReaction{}); err != nil {
+		t.Fatal(err)
+	}
+	// Article 的 AfterSave hook 會寫 search_vector（見 db.ensureArticleSearchIndex）
+	if err := db.Exec(`ALTER TABLE articles ADD COLUMN search_vector tsvector,
+		ADD COLUMN search_version smallint NOT NULL DEFAULT 0`).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	user := models.User{Email: "author@example.com", PasswordHash: "x", DisplayName: "author"}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().UTC().Add(-time.Hour)
+	article := models.Article{Title: "測試", Slug: "test", AuthorID: user.ID, Status: "published", PublishedAt: &now}
+	if err := db.Create(&article).Error; err != nil {
+		t.Fatal(err)
+	}
+	return db, article.ID
+}
+
+// 同一 IP+UA 每次都換新 cookie（GET /like 取得），讚數只能加一次。
+func TestLikeArticleFreshCookiesSameNetworkCountOnce(t *testing.T) {
+	db, articleID := newLikeTestDB(t)
+	svc := NewArticleService(db)
+	readers := fingerprint.New("secret", time.Hour)
+
+	var firstID string
+	for i := 0; i < 5; i++ {
+		reader := readers.Identify("", "203.0.113.7", "curl/8.0")
+		if !reader.Anonymous() {
+			t.Fatal("expected a freshly minted cookie")
+		}
+		if i == 0 {
+			firstID = reader.ID
+		} else if reader.ID == firstID {
+			t.Fatal("expected a different reader ID per fresh cookie")
+		}
+		if _, err := svc.LikeArticle(articleID, reader); err != nil {
+			t.Fatalf("like #%d: %v", i+1, err)
+		}
+	}
+
+	var article models.Article
+	if err := db.Select("like_count").First(&article, articleID).Error; err != nil {
+		t.Fatal(err)
+	}
+	var rows int64
+	db.Model(&models.ArticleLike{}).Where("article_id = ?", articleID).Count(&rows)
+	if article.LikeCount != 1 || rows != 1 {
+		t.Errorf("like_count = %d, rows = %d, want 1 and 1", article.LikeCount, rows)
+	}
+
+	// 不同來源仍可按讚
+	if _, err := svc.LikeArticle(articleID, readers.Identify("", "198.51.100.9", "curl/8.0")); err != nil {
+		t.Fatal(err)
+	}
+	db.Select("like_count").First(&article, articleID)
+	if article.LikeCount != 2 {
+		t.Errorf("like_count after second source = %d, want 2", article.LikeCount)
+	}
+}
+
+// 表情回應的去重同按讚：同一 IP+UA 換新 cookie 不能重複回應同一種表情，不同表情各算一次。
+func TestAddReactionFreshCookiesSameNetworkCountOnce(t *testing.T) {
+	db, articleID := newLikeTestDB(t)
+	svc := NewArticleService(db)
+	svc.SetReactionTypes([]ReactionType{{Key: "heart", Emoji: "❤️"}, {Key: "rocket", Emoji: "🚀"}})
+	readers := fingerprint.New("secret", time.Hour)
+
+	for i := 0; i < 5; i++ {
+		if _, err := svc.AddReaction(articleID, "heart", readers.Identify("", "203.0.113.7", "curl/8.0")); err != nil {
+			t.Fatalf("reaction #%d: %v", i+1, err)
+		}
+	}
+	status, err := svc.AddReaction(articleID, "rocket", readers.Identify("", "203.0.113.7", "curl/8.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range status.Reactions {
+		if r.Count != 1 {
+			t.Errorf("%s count = %d, want 1", r.Type, r.Count)
+		}
+	}
+}
+
+// 已建立 cookie 的不同讀者在同一 NAT 後（IP+UA 相同）各自計數。
+func TestLikeArticleEstablishedReadersBehindSameNetwork(t *testing.T) {
+	db, articleID := newLikeTestDB(t)
+	svc := NewArticleService(db)
+	readers := fingerprint.New("secret", time.Nanosecond) // 任何已簽發的 cookie 都已超過週期
+	const ip, ua = "100.64.0.1", "Mozilla/5.0 (iPhone)"
+
+	for i := 0; i < 2; i++ {
+		reader := readers.Identify(readers.Identify("", ip, ua).NewCookie, ip, ua)
+		if !reader.Established {
+			t.Fatal("expected an established reader")
+		}
+		if _, err := svc.LikeArticle(articleID, reader); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var article models.Article
+	if err := db.Select("like_count").First(&article, articleID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if article.LikeCount != 2 {
+		t.Errorf("like_count = %d, want 2", article.LikeCount)
+	}
+}
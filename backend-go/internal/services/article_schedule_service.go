@@ -0,0 +1,121 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// promoteBatchSize 單次排程最多轉換的文章數（避免一次鎖太多 row）。
+const promoteBatchSize = 100
+
+// PromoteDueScheduled 把 published_at 已到期的 scheduled 文章轉為 published。
+//
+// 多副本安全：以 SELECT ... FOR UPDATE SKIP LOCKED 鎖定候選 row，
+// 其他副本同時執行時會跳過已被鎖住的文章；UPDATE 再以 status='scheduled'
+// 作為條件，重複執行不會重複轉換（idempotent）。
+// 每篇轉換都會寫一筆 ArticlePublishLog（actor 標記執行者）。
+func (s *ArticleService) PromoteDueScheduled(actor string) ([]uint, error) {
+	now := time.Now().UTC()
+	var promoted []uint
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var due []models.Article
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Select("id", "published_at").
+			Where("status = ? AND published_at <= ?", "scheduled", now).
+			Order("published_at ASC").
+			Limit(promoteBatchSize).
+			Find(&due).Error; err != nil {
+			return err
+		}
+
+		for _, a := range due {
+			res := tx.Model(&models.Article{}).
+				Where("id = ? AND status = ?", a.ID, "scheduled").
+				UpdateColumn("status", "published")
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				continue
+			}
+			if err := recordPublishEvent(tx, a.ID, "scheduled", "published", actor, a.PublishedAt); err != nil {
+				return err
+			}
+			promoted = append(promoted, a.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(promoted) > 0 {
+		log.Printf("[scheduler] promoted %d scheduled article(s) actor=%s ids=%v", len(promoted), actor, promoted)
+		s.notifyPublicChange()
+	}
+	return promoted, nil
+}
+
+// GetSchedulerOverview 排程狀態：即將到期的 scheduled 文章 + 最近的發佈轉換紀錄。
+func (s *ArticleService) GetSchedulerOverview(limit int) (upcoming []dto.ScheduledArticleDto, recent []dto.PublishLogDto, err error) {
+	var articles []models.Article
+	if err = s.db.Select("id", "title", "slug", "published_at").
+		Where("status = ?", "scheduled").
+		Order("published_at ASC").
+		Limit(limit).
+		Find(&articles).Error; err != nil {
+		return nil, nil, err
+	}
+	now := time.Now().UTC()
+	upcoming = make([]dto.ScheduledArticleDto, 0, len(articles))
+	for _, a := range articles {
+		upcoming = append(upcoming, dto.ScheduledArticleDto{
+			ID:          a.ID,
+			Title:       a.Title,
+			Slug:        a.Slug,
+			ScheduledAt: a.PublishedAt,
+			Overdue:     a.PublishedAt != nil && !a.PublishedAt.After(now),
+		})
+	}
+
+	var logs []models.ArticlePublishLog
+	if err = s.db.Order("created_at DESC").Limit(limit).Find(&logs).Error; err != nil {
+		return nil, nil, err
+	}
+	recent = make([]dto.PublishLogDto, 0, len(logs))
+	for _, l := range logs {
+		recent = append(recent, dto.PublishLogDto{
+			ID:          l.ID,
+			ArticleID:   l.ArticleID,
+			FromStatus:  l.FromStatus,
+			ToStatus:    l.ToStatus,
+			Actor:       l.Actor,
+			ScheduledAt: l.ScheduledAt,
+			CreatedAt:   l.CreatedAt,
+		})
+	}
+	return upcoming, recent, nil
+}
+
+// recordPublishEvent 寫入一筆發佈狀態轉換紀錄。
+func recordPublishEvent(tx *gorm.DB, articleID uint, from, to, actor string, scheduledAt *time.Time) error {
+	return tx.Create(&models.ArticlePublishLog{
+		ArticleID:   articleID,
+		FromStatus:  from,
+		ToStatus:    to,
+		Actor:       actor,
+		ScheduledAt: scheduledAt,
+	}).Error
+}
+
+// userActor 後台使用者操作的 actor 字串。
+func userActor(userID uint) string {
+	return fmt.Sprintf("user:%d", userID)
+}
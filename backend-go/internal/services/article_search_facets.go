@@ -0,0 +1,64 @@
+package services
+
+import (
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"gorm.io/gorm"
+)
+
+// facetRow facet 查詢的共用結果列。
+type facetRow struct {
+	ID    *uint
+	Key   *string
+	Name  *string
+	Count int
+}
+
+// searchFacets 對 ids 子查詢（SELECT articles.id ... 目前的檢索條件）計算各維度筆數。
+// 全部在 DB 端 GROUP BY，不載入命中的文章本體。
+func (s *ArticleService) searchFacets(ids *gorm.DB) (dto.SearchFacetsDto, error) {
+	var out dto.SearchFacetsDto
+
+	queries := []struct {
+		sql  string
+		dest *[]dto.FacetCountDto
+	}{
+		{`SELECT a.category_id AS id, c.slug AS key, c.name AS name, COUNT(*) AS count
+		  FROM articles a
+		  LEFT JOIN categories c ON c.id = a.category_id
+		  WHERE a.id IN (?)
+		  GROUP BY a.category_id, c.slug, c.name
+		  ORDER BY count DESC, c.name ASC`, &out.Categories},
+		{`SELECT t.id AS id, t.slug AS key, t.name AS name, COUNT(*) AS count
+		  FROM article_tags at
+		  JOIN tags t ON t.id = at.tag_id
+		  WHERE at.article_id IN (?)
+		  GROUP BY t.id, t.slug, t.name
+		  ORDER BY count DESC, t.name ASC`, &out.Tags},
+		{`SELECT a.status AS key, COUNT(*) AS count
+		  FROM articles a
+		  WHERE a.id IN (?)
+		  GROUP BY a.status
+		  ORDER BY count DESC, a.status ASC`, &out.Statuses},
+		{`SELECT to_char(a.published_at, 'YYYY-MM') AS key, COUNT(*) AS count
+		  FROM articles a
+		  WHERE a.id IN (?) AND a.published_at IS NOT NULL
+		  GROUP BY key
+		  ORDER BY key DESC`, &out.PublishMonths},
+	}
+	for _, q := range queries {
+		var rows []facetRow
+		if err := s.db.Raw(q.sql, ids).Scan(&rows).Error; err != nil {
+			return dto.SearchFacetsDto{}, err
+		}
+		*q.dest = mapFacetRows(rows)
+	}
+	return out, nil
+}
+
+func mapFacetRows(rows []facetRow) []dto.FacetCountDto {
+	out := make([]dto.FacetCountDto, len(rows))
+	for i, r := range rows {
+		out[i] = dto.FacetCountDto{ID: r.ID, Key: derefStr(r.Key), Name: derefStr(r.Name), Count: r.Count}
+	}
+	return out
+}
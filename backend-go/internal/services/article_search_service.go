@@ -9,15 +9,22 @@ import (
 	"github.com/paulhuang/paulfun-blogger/internal/apierror"
 	"github.com/paulhuang/paulfun-blogger/internal/dto"
 	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"github.com/paulhuang/paulfun-blogger/internal/textseg"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var htmlTagRe = regexp.MustCompile(`<[^>]*>`)
 
-// SearchArticles 多條件全文檢索（後台回顧用，含草稿）。
+// SearchArticles 多條件全文檢索。
+// includeUnpublished=true 用於後台回顧（含草稿，可依 status 篩選）；
+// false 用於前台（僅已發佈，忽略 status 參數）。
 // 契約見 docs/specs/2026-07-15-article-review-search-links.md：
 // Pre: q 至少 1 個詞；Post: 每筆結果必然滿足 mode 邏輯；唯讀。
-func (s *ArticleService) SearchArticles(p dto.ArticleSearchParams) (dto.PagedResponse[dto.ArticleSearchItemDto], error) {
-	empty := dto.PagedResponse[dto.ArticleSearchItemDto]{}
+//
+// 回應附帶 facets：目前查詢條件下依分類 / 標籤 / 狀態 / 發佈年月的筆數（DB 端 GROUP BY）。
+func (s *ArticleService) SearchArticles(p dto.ArticleSearchParams, includeUnpublished bool) (dto.ArticleSearchResponseDto, error) {
+	empty := dto.ArticleSearchResponseDto{}
 
 	keywords := strings.Fields(strings.TrimSpace(p.Q))
 	if len(keywords) == 0 {
@@ -33,39 +40,28 @@ func (s *ArticleService) SearchArticles(p dto.ArticleSearchParams) (dto.PagedRes
 		mode = "and"
 	}
 
-	query := s.db.Model(&models.Article{}).
-		Preload("Author").
-		Preload("Category").
-		Preload("Tags")
+	// 篩選條件只套在 query（不含 preload / 排序 / 分頁），facets 以其為子查詢共用
+	query := s.db.Model(&models.Article{})
 
-	// 每個關鍵字產生一組跨欄位 OR 條件；關鍵字之間依 mode 用 AND / OR 串接
-	var kwConds []string
-	var kwArgs []interface{}
-	for _, kw := range keywords {
-		like := "%" + strings.ToLower(kw) + "%"
-		var fieldConds []string
-		for _, f := range fields {
-			fieldConds = append(fieldConds, fmt.Sprintf("LOWER(COALESCE(%s, '')) LIKE ?", f))
-			kwArgs = append(kwArgs, like)
-		}
-		kwConds = append(kwConds, "("+strings.Join(fieldConds, " OR ")+")")
-	}
-	joiner := " AND "
-	if mode == "or" {
-		joiner = " OR "
+	// 關鍵字 → tsquery：欄位限定用權重標籤，and / or 用 & / | 串接
+	tsq := buildTSQuery(keywords, fields, mode)
+	if tsq == "" {
+		return empty, fmt.Errorf("%w: 檢索關鍵字需包含文字或數字", apierror.ErrBadRequest)
 	}
-	query = query.Where(strings.Join(kwConds, joiner), kwArgs...)
+	query = query.Where("search_vector @@ to_tsquery('simple', ?)", tsq)
 
-	if p.Status != "" {
+	if !includeUnpublished {
+		now := time.Now().UTC()
+		query = query.Where("status = ? AND published_at <= ?", "published", now)
+	} else if p.Status != "" {
 		query = query.Where("status = ?", p.Status)
 	}
 	if ids := parseCSVUints(p.CategoryIDs); len(ids) > 0 {
 		query = query.Where("category_id IN ?", ids)
 	}
 	if ids := parseCSVUints(p.TagIDs); len(ids) > 0 {
-		query = query.Joins("JOIN article_tags ON article_tags.article_id = articles.id").
-			Where("article_tags.tag_id IN ?", ids).
-			Distinct("articles.*")
+		// 用子查詢而非 JOIN + DISTINCT：DISTINCT 會讓 ORDER BY ts_rank 失效
+		query = query.Where("id IN (SELECT article_id FROM article_tags WHERE tag_id IN ?)", ids)
 	}
 
 	dateCol := "created_at"
@@ -83,11 +79,19 @@ func (s *ArticleService) SearchArticles(p dto.ArticleSearchParams) (dto.PagedRes
 		}
 	}
 
+	query = query.Session(&gorm.Session{}) // 之後分頁查詢與 facets 各自衍生，互不污染
+
 	var totalCount int64
 	if err := query.Count(&totalCount).Error; err != nil {
 		return empty, err
 	}
 
+	facets, err := s.searchFacets(query.Select("articles.id"))
+	if err != nil {
+		return empty, err
+	}
+
+	query = query.Preload("Author").Preload("Category").Preload("Tags")
 	sortBy := strings.ToLower(p.SortBy)
 	dir := "DESC"
 	if p.Descending != nil && !*p.Descending {
@@ -100,6 +104,8 @@ func (s *ArticleService) SearchArticles(p dto.ArticleSearchParams) (dto.PagedRes
 		query = query.Order("published_at " + dir)
 	case "viewcount":
 		query = query.Order("view_count " + dir)
+	case "relevance":
+		query = orderByRank(query, tsq, dir)
 	default:
 		query = query.Order("created_at " + dir)
 	}
@@ -121,12 +127,13 @@ func (s *ArticleService) SearchArticles(p dto.ArticleSearchParams) (dto.PagedRes
 		return empty, err
 	}
 
+	terms := highlightTerms(keywords)
 	items := make([]dto.ArticleSearchItemDto, len(articles))
 	for i, a := range articles {
 		items[i] = dto.ArticleSearchItemDto{
 			ArticleListItemDto: mapToListItemDto(a),
-			Snippet:            buildSnippet(a, keywords),
-			MatchedFields:      matchedFields(a, keywords),
+			Snippet:            buildSnippet(a, terms),
+			MatchedFields:      matchedFields(a, terms),
 		}
 	}
 
@@ -134,14 +141,17 @@ func (s *ArticleService) SearchArticles(p dto.ArticleSearchParams) (dto.PagedRes
 	if totalPages == 0 {
 		totalPages = 1
 	}
-	return dto.PagedResponse[dto.ArticleSearchItemDto]{
-		Items:           items,
-		TotalCount:      int(totalCount),
-		Page:            page,
-		PageSize:        pageSize,
-		TotalPages:      totalPages,
-		HasPreviousPage: page > 1,
-		HasNextPage:     page < totalPages,
+	return dto.ArticleSearchResponseDto{
+		PagedResponse: dto.PagedResponse[dto.ArticleSearchItemDto]{
+			Items:           items,
+			TotalCount:      int(totalCount),
+			Page:            page,
+			PageSize:        pageSize,
+			TotalPages:      totalPages,
+			HasPreviousPage: page > 1,
+			HasNextPage:     page < totalPages,
+		},
+		Facets: facets,
 	}, nil
 }
 
@@ -161,6 +171,90 @@ func parseSearchFields(raw string) []string {
 	return fields
 }
 
+// maxTermsPerKeyword 單一關鍵字斷詞後最多取幾個比對單位。
+const maxTermsPerKeyword = 20
+
+// searchFieldWeights 欄位 → search_vector 權重標籤（見 models.ArticleSearchVectorSQL）。
+var searchFieldWeights = map[string]string{"title": "A", "summary": "B", "content": "C"}
+
+// buildTSQuery 把關鍵字轉成 to_tsquery('simple', ...) 的查詢字串。
+//
+// 每個關鍵字以 textseg 斷詞（與建立 search_vector 時同一套規則）：英數字詞做前綴比對（tok:*），
+// 中文切成 bigram 精確比對，同一關鍵字內的比對單位以 & 串接；標點、運算子在斷詞時即被丟棄，
+// 不會造成 tsquery 語法注入。fields 非全選時加上權重標籤限定欄位（如 tok:*AB）。
+// 關鍵字之間依 mode 以 & 或 | 串接。沒有任何可用比對單位時回傳空字串。
+func buildTSQuery(keywords []string, fields []string, mode string) string {
+	labels := ""
+	if len(fields) < len(searchFieldWeights) {
+		for _, f := range fields {
+			labels += searchFieldWeights[f]
+		}
+	}
+
+	var groups []string
+	for _, kw := range keywords {
+		terms := textseg.QueryTerms(kw)
+		if len(terms) == 0 {
+			continue
+		}
+		if len(terms) > maxTermsPerKeyword {
+			terms = terms[:maxTermsPerKeyword] // 防濫用：超長中文詞只取前段 bigram
+		}
+		parts := make([]string, len(terms))
+		for i, t := range terms {
+			switch {
+			case t.Prefix:
+				parts[i] = t.Text + ":*" + labels
+			case labels != "":
+				parts[i] = t.Text + ":" + labels
+			default:
+				parts[i] = t.Text
+			}
+		}
+		groups = append(groups, "("+strings.Join(parts, " & ")+")")
+	}
+
+	joiner := " & "
+	if mode == "or" {
+		joiner = " | "
+	}
+	return strings.Join(groups, joiner)
+}
+
+// orderByRank 依 ts_rank_cd 排序：權重 A > B > C 使 title 命中排在前面，
+// cover density 讓中文 bigram 相鄰命中（原詞組完整出現）高於分散命中。同分再依建立時間新 → 舊。
+func orderByRank(query *gorm.DB, tsq, dir string) *gorm.DB {
+	return query.Clauses(clause.OrderBy{
+		Expression: clause.Expr{
+			SQL:  "ts_rank_cd(search_vector, to_tsquery('simple', ?)) " + dir + ", created_at DESC",
+			Vars: []interface{}{tsq},
+		},
+	})
+}
+
+// highlightTerms snippet / matchedFields 用的比對字串：原關鍵字在前（優先標記完整詞），
+// 其後是斷詞結果（中文 bigram 等），讓部分命中也能找到位置。
+func highlightTerms(keywords []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	add := func(t string) {
+		lt := strings.ToLower(t)
+		if t != "" && !seen[lt] {
+			seen[lt] = true
+			out = append(out, t)
+		}
+	}
+	for _, kw := range keywords {
+		add(kw)
+	}
+	for _, kw := range keywords {
+		for _, t := range textseg.QueryTerms(kw) {
+			add(t.Text)
+		}
+	}
+	return out
+}
+
 func parseCSVUints(raw string) []uint {
 	var ids []uint
 	for _, part := range strings.Split(raw, ",") {
@@ -12,19 +12,44 @@ import (
 	"github.com/paulhuang/paulfun-blogger/internal/dto"
 	"github.com/paulhuang/paulfun-blogger/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ArticleService 處理文章相關業務邏輯。
 type ArticleService struct {
 	db *gorm.DB
+
+	publicChangeHooks []func()
+	reactionTypes     []ReactionType // 可用的表情回應（SetReactionTypes）
 }
 
 func NewArticleService(db *gorm.DB) *ArticleService {
 	return &ArticleService{db: db}
 }
 
+// OnPublicChange 註冊「前台可見內容可能已變動」的回呼（發佈 / 取消發佈 / 排程上線 / 編輯 / 刪除 / 還原）。
+// 回呼在寫入成功後同步執行，應保持輕量（例如清快取）；只在啟動時註冊，不支援並行註冊。
+func (s *ArticleService) OnPublicChange(fn func()) {
+	s.publicChangeHooks = append(s.publicChangeHooks, fn)
+}
+
+// notifyPublicChangeIf 異動前或後文章為前台可見時才通知；草稿的編輯不影響前台，
+// 不必清 sitemap 快取或讓推薦索引重建。
+func (s *ArticleService) notifyPublicChangeIf(public bool) {
+	if public {
+		s.notifyPublicChange()
+	}
+}
+
+func (s *ArticleService) notifyPublicChange() {
+	for _, fn := range s.publicChangeHooks {
+		fn()
+	}
+}
+
 // GetArticles 查詢文章列表（分頁 + 篩選）。
 // includeUnpublished=true 用於後台；false 用於前台（僅顯示已發佈）。
+// 分類 / 標籤 slug 不存在時回 ErrNotFound；tagMode 不合法時回 ErrBadRequest。
 func (s *ArticleService) GetArticles(q dto.ArticleQueryParams, includeUnpublished bool) (dto.PagedResponse[dto.ArticleListItemDto], error) {
 	query := s.db.Model(&models.Article{}).
 		Preload("Author").
@@ -38,21 +63,15 @@ func (s *ArticleService) GetArticles(q dto.ArticleQueryParams, includeUnpublishe
 		query = query.Where("status = ?", q.Status)
 	}
 
-	if q.CategoryID != nil {
-		query = query.Where("category_id = ?", *q.CategoryID)
-	}
-
-	if q.TagID != nil {
-		query = query.Joins("JOIN article_tags ON article_tags.article_id = articles.id").
-			Where("article_tags.tag_id = ?", *q.TagID)
+	query, err := s.applyTaxonomyFilters(query, q)
+	if err != nil {
+		return dto.PagedResponse[dto.ArticleListItemDto]{}, err
 	}
 
-	if q.Search != "" {
-		like := "%" + strings.ToLower(q.Search) + "%"
-		query = query.Where(
-			"LOWER(title) LIKE ? OR LOWER(summary) LIKE ? OR LOWER(content) LIKE ?",
-			like, like, like,
-		)
+	// 全文檢索：每個詞都需命中（title / summary / content 任一）
+	tsq := buildTSQuery(strings.Fields(q.Search), parseSearchFields(""), "and")
+	if tsq != "" {
+		query = query.Where("search_vector @@ to_tsquery('simple', ?)", tsq)
 	}
 
 	var totalCount int64
@@ -72,6 +91,12 @@ func (s *ArticleService) GetArticles(q dto.ArticleQueryParams, includeUnpublishe
 		query = query.Order("published_at " + dir)
 	case "viewcount":
 		query = query.Order("view_count " + dir)
+	case "relevance":
+		if tsq != "" {
+			query = orderByRank(query, tsq, dir)
+		} else {
+			query = query.Order("created_at " + dir) // 沒有檢索詞時 relevance 無意義
+		}
 	default:
 		query = query.Order("created_at " + dir)
 	}
@@ -112,6 +137,7 @@ func (s *ArticleService) GetArticleByID(id uint) (*dto.ArticleDto, error) {
 		return nil, apierror.ErrNotFound
 	}
 	d := mapToDto(article)
+	s.attachReactions(&d)
 	return &d, nil
 }
 
@@ -125,6 +151,7 @@ func (s *ArticleService) GetPublishedArticleByID(id uint) (*dto.ArticleDto, erro
 		return nil, apierror.ErrNotFound
 	}
 	d := mapToDto(article)
+	s.attachReactions(&d)
 	return &d, nil
 }
 
@@ -132,12 +159,7 @@ func (s *ArticleService) GetPublishedArticleByID(id uint) (*dto.ArticleDto, erro
 func (s *ArticleService) CreateArticle(req dto.CreateArticleRequest, authorID uint) (*dto.ArticleDto, error) {
 	slug := generateSlug(req.Title)
 	base := slug
-	for i := 1; ; i++ {
-		var cnt int64
-		s.db.Model(&models.Article{}).Where("slug = ?", slug).Count(&cnt)
-		if cnt == 0 {
-			break
-		}
+	for i := 1; slugTaken(s.db, slug); i++ {
 		slug = fmt.Sprintf("%s-%d", base, i)
 	}
 
@@ -168,7 +190,8 @@ func (s *ArticleService) CreateArticle(req dto.CreateArticleRequest, authorID ui
 }
 
 // archiveArticle 在更新前把文章當前版本存入 article_archives 表。
-func (s *ArticleService) archiveArticle(article *models.Article, userID uint) error {
+// 必須與更新本身在同一個 transaction 內呼叫，避免留下沒有對應更新的 archive。
+func (s *ArticleService) archiveArticle(tx *gorm.DB, article *models.Article, userID uint) error {
 	tagIDs := make([]uint, len(article.Tags))
 	for i, t := range article.Tags {
 		tagIDs[i] = t.ID
@@ -189,105 +212,150 @@ func (s *ArticleService) archiveArticle(article *models.Article, userID uint) er
 		ArchivedAt: time.Now().UTC(),
 		ArchivedBy: userID,
 	}
-	return s.db.Create(&archive).Error
+	return tx.Create(&archive).Error
 }
 
-// UpdateArticle 更新文章內容（僅作者或 admin 可操作）。
-// 更新前自動建立歷史版本備份。
-func (s *ArticleService) UpdateArticle(id uint, req dto.UpdateArticleRequest, userID uint) (*dto.ArticleDto, error) {
+// loadArticleForEdit 在 transaction 內以 SELECT ... FOR UPDATE 鎖住文章後載入（含 Tags），
+// 並檢查編輯權限與樂觀鎖版本。鎖持有到 transaction 結束，確保「比對版本 → 寫入」之間
+// 不會有其他寫入插隊。
+func (s *ArticleService) loadArticleForEdit(tx *gorm.DB, id uint, userID uint, expectedVersion *int) (*models.Article, error) {
+	var locked models.Article
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").First(&locked, id).Error; err != nil {
+		return nil, apierror.ErrNotFound
+	}
 	var article models.Article
-	if err := s.db.Preload("Tags").First(&article, id).Error; err != nil {
+	if err := tx.Preload("Tags").First(&article, id).Error; err != nil {
 		return nil, apierror.ErrNotFound
 	}
 
 	if err := s.checkOwnerOrAdmin(article.AuthorID, userID); err != nil {
 		return nil, err
 	}
+	if expectedVersion != nil && *expectedVersion != article.Version {
+		return nil, &apierror.VersionConflictError{Current: article.Version}
+	}
+	return &article, nil
+}
 
-	// 存檔舊版本
-	if err := s.archiveArticle(&article, userID); err != nil {
-		return nil, err
+// replaceTags 以 tagIDs 取代文章的標籤（空陣列 = 清空）。
+func replaceTags(tx *gorm.DB, article *models.Article, tagIDs []uint) error {
+	if err := tx.Model(article).Association("Tags").Clear(); err != nil {
+		return err
+	}
+	if len(tagIDs) == 0 {
+		return nil
 	}
+	var tags []models.Tag
+	if err := tx.Where("id IN ?", tagIDs).Find(&tags).Error; err != nil {
+		return err
+	}
+	return tx.Model(article).Association("Tags").Replace(tags)
+}
 
-	now := time.Now().UTC()
-	article.Title = req.Title
-	article.Summary = req.Summary
-	article.Content = req.Content
-	article.CoverImage = req.CoverImage
-	article.CategoryID = req.CategoryID
-	article.UpdatedAt = &now
-	article.Version++
+// UpdateArticle 更新文章內容（僅作者或 admin 可操作）。
+// 更新前自動建立歷史版本備份；備份與更新在同一個 transaction。
+// req.ExpectedVersion 有值時做樂觀鎖檢查，不符回 VersionConflictError。
+func (s *ArticleService) UpdateArticle(id uint, req dto.UpdateArticleRequest, userID uint) (*dto.ArticleDto, error) {
+	var article *models.Article
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if article, err = s.loadArticleForEdit(tx, id, userID, req.ExpectedVersion); err != nil {
+			return err
+		}
 
-	s.db.Model(&article).Association("Tags").Clear()
-	if len(req.TagIDs) > 0 {
-		var tags []models.Tag
-		s.db.Where("id IN ?", req.TagIDs).Find(&tags)
-		s.db.Model(&article).Association("Tags").Replace(tags)
-	}
+		// 存檔舊版本
+		if err := s.archiveArticle(tx, article, userID); err != nil {
+			return err
+		}
+
+		// slug 選填：有傳才改，舊 slug 寫入歷史供轉址
+		if req.Slug != nil {
+			if err := changeSlug(tx, article, *req.Slug); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now().UTC()
+		article.Title = req.Title
+		article.Summary = req.Summary
+		article.Content = req.Content
+		article.CoverImage = req.CoverImage
+		article.CategoryID = req.CategoryID
+		article.UpdatedAt = &now
+		article.Version++
 
-	if err := s.db.Save(&article).Error; err != nil {
+		if err := replaceTags(tx, article, req.TagIDs); err != nil {
+			return err
+		}
+		return tx.Save(article).Error
+	})
+	if err != nil {
 		return nil, err
 	}
+	s.notifyPublicChangeIf(isPubliclyVisible(*article, time.Now().UTC()))
 
-	s.db.Preload("Author").Preload("Category").Preload("Tags").First(&article, article.ID)
-	d := mapToDto(article)
+	s.db.Preload("Author").Preload("Category").Preload("Tags").First(article, article.ID)
+	d := mapToDto(*article)
 	return &d, nil
 }
 
 // PatchArticle 局部更新文章，只修改 fields 中標記為 true 的欄位。
-// 更新前自動建立歷史版本備份。
+// 備份、樂觀鎖規則同 UpdateArticle。
 func (s *ArticleService) PatchArticle(id uint, req dto.PatchArticleRequest, fields dto.PatchArticleFields, userID uint) (*dto.ArticleDto, error) {
-	var article models.Article
-	if err := s.db.Preload("Tags").First(&article, id).Error; err != nil {
-		return nil, apierror.ErrNotFound
-	}
+	var article *models.Article
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if article, err = s.loadArticleForEdit(tx, id, userID, req.ExpectedVersion); err != nil {
+			return err
+		}
 
-	if err := s.checkOwnerOrAdmin(article.AuthorID, userID); err != nil {
-		return nil, err
-	}
+		// 存檔舊版本
+		if err := s.archiveArticle(tx, article, userID); err != nil {
+			return err
+		}
 
-	// 存檔舊版本
-	if err := s.archiveArticle(&article, userID); err != nil {
-		return nil, err
-	}
+		now := time.Now().UTC()
 
-	now := time.Now().UTC()
+		if fields.HasTitle && req.Title != nil {
+			article.Title = *req.Title
+		}
+		if fields.HasSummary {
+			article.Summary = req.Summary // 可以是 nil（清空）或有值
+		}
+		if fields.HasContent {
+			article.Content = req.Content
+		}
+		if fields.HasCoverImage {
+			article.CoverImage = req.CoverImage
+		}
+		if fields.HasCategoryID {
+			article.CategoryID = req.CategoryID
+		}
+		if fields.HasSlug && req.Slug != nil {
+			if err := changeSlug(tx, article, *req.Slug); err != nil {
+				return err
+			}
+		}
 
-	if fields.HasTitle && req.Title != nil {
-		article.Title = *req.Title
-	}
-	if fields.HasSummary {
-		article.Summary = req.Summary // 可以是 nil（清空）或有值
-	}
-	if fields.HasContent {
-		article.Content = req.Content
-	}
-	if fields.HasCoverImage {
-		article.CoverImage = req.CoverImage
-	}
-	if fields.HasCategoryID {
-		article.CategoryID = req.CategoryID
-	}
+		article.UpdatedAt = &now
+		article.Version++
 
-	article.UpdatedAt = &now
-	article.Version++
-
-	// 只有明確傳送 tagIds 時才更新標籤
-	if fields.HasTagIDs {
-		s.db.Model(&article).Association("Tags").Clear()
-		if len(req.TagIDs) > 0 {
-			var tags []models.Tag
-			s.db.Where("id IN ?", req.TagIDs).Find(&tags)
-			s.db.Model(&article).Association("Tags").Replace(tags)
+		// 只有明確傳送 tagIds 時才更新標籤
+		if fields.HasTagIDs {
+			if err := replaceTags(tx, article, req.TagIDs); err != nil {
+				return err
+			}
 		}
-	}
-
-	if err := s.db.Save(&article).Error; err != nil {
+		return tx.Save(article).Error
+	})
+	if err != nil {
 		return nil, err
 	}
+	s.notifyPublicChangeIf(isPubliclyVisible(*article, time.Now().UTC()))
 
-	s.db.Preload("Author").Preload("Category").Preload("Tags").First(&article, article.ID)
-	d := mapToDto(article)
+	s.db.Preload("Author").Preload("Category").Preload("Tags").First(article, article.ID)
+	d := mapToDto(*article)
 	return &d, nil
 }
 
@@ -320,6 +388,10 @@ func (s *ArticleService) GetArticleArchiveDetail(archiveID uint) (*dto.ArticleAr
 	if err := s.db.First(&archive, archiveID).Error; err != nil {
 		return nil, apierror.ErrNotFound
 	}
+	content, err := archiveContent(&archive)
+	if err != nil {
+		return nil, err
+	}
 
 	return &dto.ArticleArchiveDetailDto{
 		ID:         archive.ID,
@@ -327,7 +399,7 @@ func (s *ArticleService) GetArticleArchiveDetail(archiveID uint) (*dto.ArticleAr
 		Title:      archive.Title,
 		Slug:       archive.Slug,
 		Summary:    archive.Summary,
-		Content:    archive.Content,
+		Content:    content,
 		CoverImage: archive.CoverImage,
 		CategoryID: archive.CategoryID,
 		Status:     archive.Status,
@@ -338,67 +410,63 @@ func (s *ArticleService) GetArticleArchiveDetail(archiveID uint) (*dto.ArticleAr
 	}, nil
 }
 
-// RestoreArticle 從歷史版本還原文章（還原前會先存檔當前版本）。
+// RestoreArticle 從歷史版本還原文章（還原前會先存檔當前版本，兩者同一個 transaction）。
 func (s *ArticleService) RestoreArticle(articleID uint, archiveID uint, userID uint) (*dto.ArticleDto, error) {
-	var article models.Article
-	if err := s.db.Preload("Tags").First(&article, articleID).Error; err != nil {
-		return nil, apierror.ErrNotFound
-	}
-
-	if err := s.checkOwnerOrAdmin(article.AuthorID, userID); err != nil {
-		return nil, err
-	}
-
-	var archive models.ArticleArchive
-	if err := s.db.First(&archive, archiveID).Error; err != nil {
-		return nil, apierror.ErrNotFound
-	}
-	if archive.ArticleID != articleID {
-		return nil, apierror.ErrNotFound
-	}
-
-	// 存檔當前版本
-	if err := s.archiveArticle(&article, userID); err != nil {
-		return nil, err
-	}
+	var article *models.Article
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if article, err = s.loadArticleForEdit(tx, articleID, userID, nil); err != nil {
+			return err
+		}
 
-	now := time.Now().UTC()
-	article.Title = archive.Title
-	article.Summary = archive.Summary
-	article.Content = archive.Content
-	article.CoverImage = archive.CoverImage
-	article.CategoryID = archive.CategoryID
-	article.UpdatedAt = &now
-	article.Version++
+		var archive models.ArticleArchive
+		if err := tx.First(&archive, archiveID).Error; err != nil {
+			return apierror.ErrNotFound
+		}
+		if archive.ArticleID != articleID {
+			return apierror.ErrNotFound
+		}
+		content, err := archiveContent(&archive)
+		if err != nil {
+			return err
+		}
 
-	// 還原標籤
-	var tagIDs []uint
-	json.Unmarshal([]byte(archive.TagIDs), &tagIDs)
-	s.db.Model(&article).Association("Tags").Clear()
-	if len(tagIDs) > 0 {
-		var tags []models.Tag
-		s.db.Where("id IN ?", tagIDs).Find(&tags)
-		s.db.Model(&article).Association("Tags").Replace(tags)
-	}
+		// 存檔當前版本
+		if err := s.archiveArticle(tx, article, userID); err != nil {
+			return err
+		}
 
-	if err := s.db.Save(&article).Error; err != nil {
+		now := time.Now().UTC()
+		article.Title = archive.Title
+		article.Summary = archive.Summary
+		article.Content = content
+		article.CoverImage = archive.CoverImage
+		article.CategoryID = archive.CategoryID
+		article.UpdatedAt = &now
+		article.Version++
+
+		// 還原標籤
+		var tagIDs []uint
+		json.Unmarshal([]byte(archive.TagIDs), &tagIDs)
+		if err := replaceTags(tx, article, tagIDs); err != nil {
+			return err
+		}
+		return tx.Save(article).Error
+	})
+	if err != nil {
 		return nil, err
 	}
+	s.notifyPublicChangeIf(isPubliclyVisible(*article, time.Now().UTC()))
 
-	s.db.Preload("Author").Preload("Category").Preload("Tags").First(&article, article.ID)
-	d := mapToDto(article)
+	s.db.Preload("Author").Preload("Category").Preload("Tags").First(article, article.ID)
+	d := mapToDto(*article)
 	return &d, nil
 }
 
-// DeleteArticle 刪除文章（僅作者或 admin 可操作）。
-//
-// 必須在 transaction 內清理：
-//  1. article_tags pivot rows — m2m 預設 FK 是 RESTRICT，不先清會 FK violation
-//  2. article_archives — model 上沒設 FK，但留著是 orphan 垃圾資料
-//  3. article 本身
+// DeleteArticle 把文章移到垃圾桶（僅作者或 admin 可操作）。
 //
-// 此前未做 (1) 導致有 tags 的文章 hard delete 失敗回 500（GORM many2many 預設
-// FK 無 ON DELETE CASCADE）。
+// 只設定 deleted_at / deleted_by；tag pivot、archives、知識串連、slug 歷史、留言
+// 全部保留，從垃圾桶還原時原樣回來。真正的 hard delete（含留言清除）見 purgeArticle。
 func (s *ArticleService) DeleteArticle(id uint, userID uint) error {
 	var article models.Article
 	if err := s.db.First(&article, id).Error; err != nil {
@@ -409,22 +477,15 @@ func (s *ArticleService) DeleteArticle(id uint, userID uint) error {
 		return err
 	}
 
-	return s.db.Transaction(func(tx *gorm.DB) error {
-		// (1) 清 m2m pivot
-		if err := tx.Model(&article).Association("Tags").Clear(); err != nil {
-			return err
-		}
-		// (2) 清 archives（避免 dangling orphan rows）
-		if err := tx.Where("article_id = ?", id).Delete(&models.ArticleArchive{}).Error; err != nil {
-			return err
-		}
-		// (2a) 清知識串連（雙向），防 FK violation
-		if err := tx.Where("from_article_id = ? OR to_article_id = ?", id, id).Delete(&models.ArticleLink{}).Error; err != nil {
-			return err
-		}
-		// (3) 刪 article 本身
-		return tx.Delete(&article).Error
-	})
+	wasPublic := isPubliclyVisible(article, time.Now().UTC())
+	if err := s.db.Model(&article).UpdateColumns(map[string]any{
+		"deleted_at": time.Now().UTC(),
+		"deleted_by": userID,
+	}).Error; err != nil {
+		return err
+	}
+	s.notifyPublicChangeIf(wasPublic)
+	return nil
 }
 
 // PublishArticle 發佈文章（立即或排程）。
@@ -439,7 +500,11 @@ func (s *ArticleService) PublishArticle(id uint, req *dto.PublishArticleRequest,
 	}
 
 	now := time.Now().UTC()
+	fromStatus := article.Status
+	wasPublic := isPubliclyVisible(article, now)
+	var scheduledAt *time.Time
 	if req != nil && req.ScheduledAt != nil {
+		scheduledAt = req.ScheduledAt
 		article.Status = "scheduled"
 		article.PublishedAt = req.ScheduledAt
 	} else {
@@ -448,9 +513,16 @@ func (s *ArticleService) PublishArticle(id uint, req *dto.PublishArticleRequest,
 	}
 	article.UpdatedAt = &now
 
-	if err := s.db.Save(&article).Error; err != nil {
+	// 狀態變更與發佈紀錄同一個交易：紀錄寫不進去就不變更狀態
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&article).Error; err != nil {
+			return err
+		}
+		return recordPublishEvent(tx, article.ID, fromStatus, article.Status, userActor(userID), scheduledAt)
+	}); err != nil {
 		return nil, err
 	}
+	s.notifyPublicChangeIf(wasPublic || isPubliclyVisible(article, now))
 
 	d := mapToDto(article)
 	return &d, nil
@@ -468,24 +540,26 @@ func (s *ArticleService) UnpublishArticle(id uint, userID uint) (*dto.ArticleDto
 	}
 
 	now := time.Now().UTC()
+	fromStatus := article.Status
+	wasPublic := isPubliclyVisible(article, now)
 	article.Status = "draft"
 	article.PublishedAt = nil
 	article.UpdatedAt = &now
 
-	if err := s.db.Save(&article).Error; err != nil {
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&article).Error; err != nil {
+			return err
+		}
+		return recordPublishEvent(tx, article.ID, fromStatus, "draft", userActor(userID), nil)
+	}); err != nil {
 		return nil, err
 	}
+	s.notifyPublicChangeIf(wasPublic)
 
 	d := mapToDto(article)
 	return &d, nil
 }
 
-// IncrementViewCount 非同步更新瀏覽數（fire-and-forget）。
-func (s *ArticleService) IncrementViewCount(id uint) {
-	s.db.Model(&models.Article{}).Where("id = ?", id).
-		UpdateColumn("view_count", gorm.Expr("view_count + 1"))
-}
-
 // GetCategories 取得所有分類（含已發佈文章數）。
 func (s *ArticleService) GetCategories() ([]dto.CategoryDto, error) {
 	type catWithCount struct {
@@ -507,6 +581,7 @@ func (s *ArticleService) GetCategories() ([]dto.CategoryDto, error) {
 		       ON a.category_id = c.id
 		          AND a.status = 'published'
 		          AND a.published_at <= ?
+		          AND a.deleted_at IS NULL
 		GROUP BY c.id, c.name, c.slug, c.parent_id, c.sort_order
 		ORDER BY c.sort_order ASC
 	`, now).Scan(&rows).Error; err != nil {
@@ -527,21 +602,41 @@ func (s *ArticleService) GetCategories() ([]dto.CategoryDto, error) {
 	return result, nil
 }
 
-// GetTags 取得所有標籤。
+// GetTags 取得所有標籤（含已發佈文章數）。
 func (s *ArticleService) GetTags() ([]dto.TagDto, error) {
-	var tags []models.Tag
-	if err := s.db.Find(&tags).Error; err != nil {
+	now := time.Now().UTC()
+	var rows []dto.TagDto
+	if err := s.db.Raw(`
+		SELECT t.id, t.name, t.slug,
+		       COUNT(a.id) AS article_count
+		FROM tags t
+		LEFT JOIN article_tags at ON at.tag_id = t.id
+		LEFT JOIN articles a
+		       ON a.id = at.article_id
+		          AND a.status = 'published'
+		          AND a.published_at <= ?
+		          AND a.deleted_at IS NULL
+		GROUP BY t.id, t.name, t.slug
+		ORDER BY t.name ASC
+	`, now).Scan(&rows).Error; err != nil {
 		return nil, err
 	}
-	result := make([]dto.TagDto, len(tags))
-	for i, t := range tags {
-		result[i] = dto.TagDto{ID: t.ID, Name: t.Name, Slug: t.Slug}
-	}
-	return result, nil
+	return rows, nil
 }
 
 // ── 內部 helpers ──────────────────────────────────────────────────────────
 
+// slugTaken 檢查 slug 是否已被任何文章使用（含其他文章的舊 slug 與垃圾桶中的文章）。
+func slugTaken(db *gorm.DB, slug string) bool {
+	var cnt int64
+	db.Unscoped().Model(&models.Article{}).Where("slug = ?", slug).Count(&cnt)
+	if cnt > 0 {
+		return true
+	}
+	db.Model(&models.ArticleSlugHistory{}).Where("slug = ?", slug).Count(&cnt)
+	return cnt > 0
+}
+
 // checkOwnerOrAdmin 確認 userID 是文章作者或系統管理員。
 func (s *ArticleService) checkOwnerOrAdmin(ownerID, requesterID uint) error {
 	if ownerID == requesterID {
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/paulhuang/paulfun-blogger/internal/apierror"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"gorm.io/gorm"
+)
+
+// GetPublishedArticleBySlug 以 slug 取得已發佈文章（前台使用）。
+//
+// 查詢順序：目前 slug → slug 歷史。命中歷史時不回傳文章本體，
+// 而是回傳 redirect（指向目前 slug），由 handler 轉成永久轉址回應。
+func (s *ArticleService) GetPublishedArticleBySlug(slug string) (*dto.ArticleDto, *dto.SlugRedirectDto, error) {
+	now := time.Now().UTC()
+	var article models.Article
+	err := s.db.Preload("Author").Preload("Category").Preload("Tags").
+		Where("slug = ? AND status = ? AND published_at <= ?", slug, "published", now).
+		First(&article).Error
+	if err == nil {
+		d := mapToDto(article)
+		s.attachReactions(&d)
+		return &d, nil, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, err
+	}
+
+	var history models.ArticleSlugHistory
+	if err := s.db.Where("slug = ?", slug).First(&history).Error; err != nil {
+		return nil, nil, apierror.ErrNotFound
+	}
+	var current models.Article
+	if err := s.db.Select("id", "slug").
+		Where("id = ? AND status = ? AND published_at <= ?", history.ArticleID, "published", now).
+		First(&current).Error; err != nil {
+		return nil, nil, apierror.ErrNotFound // 草稿不對外洩漏存在性
+	}
+	return nil, &dto.SlugRedirectDto{ID: current.ID, Slug: current.Slug}, nil
+}
+
+// changeSlug 在 transaction 內把文章 slug 改為 newSlug，並把舊 slug 寫入歷史。
+//
+//   - newSlug 經 generateSlug 正規化；與目前相同時不做事
+//   - 不可與其他文章（含垃圾桶）的目前 slug 或歷史 slug 重複（→ 409）
+//   - 改回自己曾用過的 slug 時，該筆歷史會被移除（slug 回歸為目前 slug）
+func changeSlug(tx *gorm.DB, article *models.Article, newSlug string) error {
+	if strings.TrimSpace(newSlug) == "" {
+		return fmt.Errorf("%w: slug 不可為空", apierror.ErrBadRequest)
+	}
+	normalized := generateSlug(newSlug)
+	if normalized == article.Slug {
+		return nil
+	}
+
+	var cnt int64
+	if err := tx.Unscoped().Model(&models.Article{}).
+		Where("slug = ? AND id <> ?", normalized, article.ID).
+		Count(&cnt).Error; err != nil {
+		return err
+	}
+	if cnt > 0 {
+		return fmt.Errorf("%w: slug %q 已被其他文章使用", apierror.ErrConflict, normalized)
+	}
+
+	var history models.ArticleSlugHistory
+	err := tx.Where("slug = ?", normalized).First(&history).Error
+	switch {
+	case err == nil && history.ArticleID != article.ID:
+		return fmt.Errorf("%w: slug %q 為其他文章的舊網址", apierror.ErrConflict, normalized)
+	case err == nil:
+		if err := tx.Delete(&history).Error; err != nil {
+			return err
+		}
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return err
+	}
+
+	if err := tx.Create(&models.ArticleSlugHistory{
+		ArticleID: article.ID,
+		Slug:      article.Slug,
+	}).Error; err != nil {
+		return err
+	}
+	article.Slug = normalized
+	return nil
+}
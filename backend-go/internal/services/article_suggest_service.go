@@ -0,0 +1,182 @@
+package services
+
+import (
+	"fmt"
+	"html"
+	"time"
+
+	"github.com/paulhuang/paulfun-blogger/internal/apierror"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"github.com/paulhuang/paulfun-blogger/internal/similarity"
+)
+
+const (
+	// suggestCacheTTL 索引上限壽命。文章異動會透過 ArticleService.OnPublicChange 立即清掉；
+	// TTL 兜住標籤合併、分類調整等不經過 ArticleService 的變動。
+	suggestCacheTTL = time.Hour
+	// suggestMinScore 低於此相似度不推薦（避免只共用幾個常見詞的文章）。
+	suggestMinScore = 0.08
+
+	publicSuggestLimit = 5
+	adminSuggestLimit  = 10
+)
+
+// 各欄位在相似度計算中的權重：標題最能代表主題，其次摘要、標籤 / 分類，內文最低。
+const (
+	suggestTitleWeight   = 3
+	suggestSummaryWeight = 2
+	suggestTermWeight    = 2
+	suggestContentWeight = 1
+)
+
+// suggestionIndex 已發佈文章的相似度索引與建置時間。
+type suggestionIndex struct {
+	builtAt time.Time
+	index   *similarity.Index
+}
+
+// InvalidateSuggestions 清除相似度索引，下一次請求重建。
+func (s *ArticleLinkService) InvalidateSuggestions() {
+	s.suggestMu.Lock()
+	s.suggestIndex = nil
+	s.suggestMu.Unlock()
+}
+
+// GetSuggestions 後台：文章（含草稿）的推薦清單，排除已有任何串連的文章。
+func (s *ArticleLinkService) GetSuggestions(articleID uint) ([]dto.SuggestedArticleDto, error) {
+	var article models.Article
+	if err := s.db.First(&article, articleID).Error; err != nil {
+		return nil, apierror.ErrNotFound
+	}
+
+	var links []models.ArticleLink
+	if err := s.db.Where("from_article_id = ? OR to_article_id = ?", articleID, articleID).
+		Find(&links).Error; err != nil {
+		return nil, err
+	}
+	linked := map[uint]bool{}
+	for _, l := range links {
+		linked[l.FromArticleID] = true
+		linked[l.ToArticleID] = true
+	}
+	return s.suggest(&article, adminSuggestLimit, func(id uint) bool { return linked[id] })
+}
+
+// PromoteSuggestion 把推薦文章轉為 related 串連（from = articleID）。目標須為已發佈文章。
+func (s *ArticleLinkService) PromoteSuggestion(articleID, targetID uint, note *string) (*dto.ArticleLinkDto, error) {
+	var count int64
+	if err := s.db.Model(&models.Article{}).
+		Where("id = ? AND status = ? AND published_at <= ?", targetID, "published", time.Now().UTC()).
+		Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("%w: 推薦文章不存在或未發佈", apierror.ErrNotFound)
+	}
+	return s.CreateLink(articleID, &dto.CreateArticleLinkRequest{
+		ToArticleID: targetID,
+		Relation:    models.LinkRelationRelated,
+		Note:        note,
+	})
+}
+
+// suggest 依內容相似度找出前 limit 篇已發佈文章。文章本身未發佈（草稿、排程）時以其內容即時計算。
+func (s *ArticleLinkService) suggest(article *models.Article, limit int, exclude func(uint) bool) ([]dto.SuggestedArticleDto, error) {
+	idx, err := s.suggestionIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []similarity.Match
+	if idx.Contains(article.ID) {
+		matches = idx.Similar(article.ID, limit, suggestMinScore, exclude)
+	} else {
+		if err := s.db.Model(article).Association("Tags").Find(&article.Tags); err != nil {
+			return nil, err
+		}
+		matches = idx.SimilarTo(suggestDocument(*article), limit, suggestMinScore, exclude)
+	}
+	if len(matches) == 0 {
+		return []dto.SuggestedArticleDto{}, nil
+	}
+
+	ids := make([]uint, len(matches))
+	for i, m := range matches {
+		ids[i] = m.ID
+	}
+	// 索引建好後才下架的文章在這裡濾掉
+	var articles []models.Article
+	if err := s.db.Select("id", "title", "slug", "published_at").
+		Where("id IN ? AND status = ? AND published_at <= ?", ids, "published", time.Now().UTC()).
+		Find(&articles).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.Article, len(articles))
+	for _, a := range articles {
+		byID[a.ID] = a
+	}
+
+	out := make([]dto.SuggestedArticleDto, 0, len(matches))
+	for _, m := range matches {
+		a, ok := byID[m.ID]
+		if !ok {
+			continue
+		}
+		out = append(out, dto.SuggestedArticleDto{
+			ID:          a.ID,
+			Title:       a.Title,
+			Slug:        a.Slug,
+			PublishedAt: fmtTimePtr(a.PublishedAt),
+			Score:       m.Score,
+		})
+	}
+	return out, nil
+}
+
+// suggestionIndex 取得（必要時重建）已發佈文章的相似度索引。
+func (s *ArticleLinkService) suggestionIndex() (*similarity.Index, error) {
+	s.suggestMu.Lock()
+	defer s.suggestMu.Unlock()
+	if s.suggestIndex != nil && time.Since(s.suggestIndex.builtAt) < suggestCacheTTL {
+		return s.suggestIndex.index, nil
+	}
+
+	var articles []models.Article
+	if err := s.db.Preload("Tags").
+		Select("id", "title", "summary", "content", "category_id").
+		Where("status = ? AND published_at <= ?", "published", time.Now().UTC()).
+		Find(&articles).Error; err != nil {
+		return nil, err
+	}
+	docs := make([]similarity.Document, len(articles))
+	for i, a := range articles {
+		docs[i] = suggestDocument(a)
+	}
+
+	s.suggestIndex = &suggestionIndex{builtAt: time.Now(), index: similarity.Build(docs)}
+	return s.suggestIndex.index, nil
+}
+
+// suggestDocument 文章 → 相似度文件：標題、摘要、去標籤後的內文，加上標籤與分類詞。
+func suggestDocument(a models.Article) similarity.Document {
+	doc := similarity.Document{
+		ID:         a.ID,
+		Fields:     []similarity.Field{{Text: a.Title, Weight: suggestTitleWeight}},
+		TermWeight: suggestTermWeight,
+	}
+	if a.Summary != nil {
+		doc.Fields = append(doc.Fields, similarity.Field{Text: *a.Summary, Weight: suggestSummaryWeight})
+	}
+	if a.Content != nil {
+		plain := html.UnescapeString(htmlTagRe.ReplaceAllString(*a.Content, " "))
+		doc.Fields = append(doc.Fields, similarity.Field{Text: plain, Weight: suggestContentWeight})
+	}
+	for _, t := range a.Tags {
+		doc.Terms = append(doc.Terms, fmt.Sprintf("tag:%d", t.ID))
+	}
+	if a.CategoryID != nil {
+		doc.Terms = append(doc.Terms, fmt.Sprintf("category:%d", *a.CategoryID))
+	}
+	return doc
+}
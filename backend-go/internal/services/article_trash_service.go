@@ -0,0 +1,221 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"github.com/paulhuang/paulfun-blogger/internal/apierror"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"gorm.io/gorm"
+)
+
+// ListTrash 列出垃圾桶中的文章（最近刪除的在前）。
+// retentionDays 用來計算每篇的 purgeAfter。
+func (s *ArticleService) ListTrash(q dto.TrashQueryParams, retentionDays int) (dto.PagedResponse[dto.TrashedArticleDto], error) {
+	query := s.db.Unscoped().Model(&models.Article{}).
+		Preload("Author").
+		Preload("Category").
+		Where("deleted_at IS NOT NULL")
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return dto.PagedResponse[dto.TrashedArticleDto]{}, err
+	}
+
+	page := q.GetPage()
+	pageSize := q.GetPageSize()
+
+	var articles []models.Article
+	if err := query.Order("deleted_at DESC").
+		Offset((page - 1) * pageSize).Limit(pageSize).
+		Find(&articles).Error; err != nil {
+		return dto.PagedResponse[dto.TrashedArticleDto]{}, err
+	}
+
+	// 每篇的歷史版本數（還原時會原樣保留）
+	ids := make([]uint, len(articles))
+	for i, a := range articles {
+		ids[i] = a.ID
+	}
+	type archiveCount struct {
+		ArticleID uint
+		Cnt       int64
+	}
+	var counts []archiveCount
+	if len(ids) > 0 {
+		if err := s.db.Model(&models.ArticleArchive{}).
+			Select("article_id, COUNT(*) AS cnt").
+			Where("article_id IN ?", ids).
+			Group("article_id").
+			Scan(&counts).Error; err != nil {
+			return dto.PagedResponse[dto.TrashedArticleDto]{}, err
+		}
+	}
+	countByID := map[uint]int64{}
+	for _, c := range counts {
+		countByID[c.ArticleID] = c.Cnt
+	}
+
+	items := make([]dto.TrashedArticleDto, len(articles))
+	for i, a := range articles {
+		var cat *dto.CategoryDto
+		if a.Category != nil {
+			cat = &dto.CategoryDto{
+				ID:        a.Category.ID,
+				Name:      a.Category.Name,
+				Slug:      a.Category.Slug,
+				ParentID:  a.Category.ParentID,
+				SortOrder: a.Category.SortOrder,
+			}
+		}
+		items[i] = dto.TrashedArticleDto{
+			ID:           a.ID,
+			Title:        a.Title,
+			Slug:         a.Slug,
+			Status:       a.Status,
+			Category:     cat,
+			Author:       mapToUserDto(&a.Author),
+			Version:      a.Version,
+			DeletedAt:    a.DeletedAt.Time,
+			DeletedBy:    a.DeletedBy,
+			PurgeAfter:   a.DeletedAt.Time.AddDate(0, 0, retentionDays),
+			ArchiveCount: countByID[a.ID],
+		}
+	}
+
+	totalPages := (int(totalCount) + pageSize - 1) / pageSize
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	return dto.PagedResponse[dto.TrashedArticleDto]{
+		Items:           items,
+		TotalCount:      int(totalCount),
+		Page:            page,
+		PageSize:        pageSize,
+		TotalPages:      totalPages,
+		HasPreviousPage: page > 1,
+		HasNextPage:     page < totalPages,
+	}, nil
+}
+
+// RestoreFromTrash 從垃圾桶還原文章；tags、archives、知識串連皆未動過，原樣回來。
+func (s *ArticleService) RestoreFromTrash(id uint, userID uint) (*dto.ArticleDto, error) {
+	article, err := s.findTrashed(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkOwnerOrAdmin(article.AuthorID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Unscoped().Model(article).UpdateColumns(map[string]any{
+		"deleted_at": nil,
+		"deleted_by": nil,
+	}).Error; err != nil {
+		return nil, err
+	}
+	restored := *article
+	restored.DeletedAt = gorm.DeletedAt{}
+	s.notifyPublicChangeIf(isPubliclyVisible(restored, time.Now().UTC()))
+
+	return s.GetArticleByID(id)
+}
+
+// PurgeArticle 立即永久刪除垃圾桶中的單篇文章（不在垃圾桶 → 404）。
+func (s *ArticleService) PurgeArticle(id uint, userID uint) error {
+	article, err := s.findTrashed(id)
+	if err != nil {
+		return err
+	}
+	if err := s.checkOwnerOrAdmin(article.AuthorID, userID); err != nil {
+		return err
+	}
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		return purgeArticle(tx, article)
+	}); err != nil {
+		return err
+	}
+	log.Printf("[article] purged article_id=%d actor=%s", id, userActor(userID))
+	return nil
+}
+
+// PurgeExpiredTrash 永久刪除在垃圾桶超過 retentionDays 天的文章（purge job / admin 使用）。
+// 每篇各自一個 transaction，單篇失敗不影響其他篇。
+func (s *ArticleService) PurgeExpiredTrash(retentionDays int) ([]uint, error) {
+	cutoff := time.Now().UTC().AddDate(0, 0, -retentionDays)
+	var articles []models.Article
+	if err := s.db.Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Find(&articles).Error; err != nil {
+		return nil, err
+	}
+
+	purged := []uint{}
+	for i := range articles {
+		a := &articles[i]
+		if err := s.db.Transaction(func(tx *gorm.DB) error {
+			return purgeArticle(tx, a)
+		}); err != nil {
+			log.Printf("[article] purge 失敗 article_id=%d: %v", a.ID, err)
+			continue
+		}
+		purged = append(purged, a.ID)
+	}
+	if len(purged) > 0 {
+		log.Printf("[article] purged %d expired article(s) from trash: %v", len(purged), purged)
+	}
+	return purged, nil
+}
+
+// findTrashed 取得垃圾桶中的文章。
+func (s *ArticleService) findTrashed(id uint) (*models.Article, error) {
+	var article models.Article
+	if err := s.db.Unscoped().
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		First(&article).Error; err != nil {
+		return nil, apierror.ErrNotFound
+	}
+	return &article, nil
+}
+
+// purgeArticle 在 transaction 內永久刪除文章與所有關聯資料：
+//  1. article_tags pivot rows — m2m 預設 FK 是 RESTRICT，不先清會 FK violation
+//  2. article_archives — model 上沒設 FK，但留著是 orphan 垃圾資料
+//  3. 知識串連（雙向），防 FK violation
+//  4. 舊 slug 紀錄（釋放 slug 供其他文章使用）
+//  5. 留言（含回覆）、按讚與表情回應紀錄、每日瀏覽統計與讀者分析、系列成員資格
+//  6. article 本身
+func purgeArticle(tx *gorm.DB, article *models.Article) error {
+	if err := tx.Model(article).Association("Tags").Clear(); err != nil {
+		return err
+	}
+	if err := tx.Where("article_id = ?", article.ID).Delete(&models.ArticleArchive{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("from_article_id = ? OR to_article_id = ?", article.ID, article.ID).Delete(&models.ArticleLink{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("article_id = ?", article.ID).Delete(&models.ArticleSlugHistory{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("article_id = ?", article.ID).Delete(&models.Comment{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("article_id = ?", article.ID).Delete(&models.ArticleLike{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("article_id = ?", article.ID).Delete(&models.ArticleReaction{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("article_id = ?", article.ID).Delete(&models.ArticleStat{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("article_id = ?", article.ID).Delete(&models.PageViewDaily{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("article_id = ?", article.ID).Delete(&models.SeriesArticle{}).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Delete(article).Error
+}
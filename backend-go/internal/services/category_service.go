@@ -154,7 +154,7 @@ func (s *CategoryService) Delete(id uint) error {
 	}
 
 	return s.db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Model(&models.Article{}).
+		if err := tx.Unscoped().Model(&models.Article{}).
 			Where("category_id = ?", id).
 			Update("category_id", uncat.ID).Error; err != nil {
 			return fmt.Errorf("reassign 文章到未分類失敗: %w", err)
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/paulhuang/paulfun-blogger/internal/apierror"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"gorm.io/gorm"
+)
+
+// GetCategoryTree 取得巢狀分類樹（前台使用），每個節點附直屬與含子孫的已發佈文章數。
+// 同層依 sortOrder 排序；parent 不存在的分類視為頂層。
+func (s *ArticleService) GetCategoryTree() ([]dto.CategoryTreeNodeDto, error) {
+	flat, err := s.GetCategories()
+	if err != nil {
+		return nil, err
+	}
+	return buildCategoryTree(flat), nil
+}
+
+func buildCategoryTree(flat []dto.CategoryDto) []dto.CategoryTreeNodeDto {
+	exists := map[uint]bool{}
+	for _, c := range flat {
+		exists[c.ID] = true
+	}
+	childrenOf := map[uint][]dto.CategoryDto{}
+	var roots []dto.CategoryDto
+	for _, c := range flat {
+		if c.ParentID == nil || !exists[*c.ParentID] {
+			roots = append(roots, c)
+			continue
+		}
+		childrenOf[*c.ParentID] = append(childrenOf[*c.ParentID], c)
+	}
+
+	visited := map[uint]bool{} // 資料異常成環時避免無限遞迴
+	var build func(nodes []dto.CategoryDto) []dto.CategoryTreeNodeDto
+	build = func(nodes []dto.CategoryDto) []dto.CategoryTreeNodeDto {
+		sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].SortOrder < nodes[j].SortOrder })
+		out := make([]dto.CategoryTreeNodeDto, 0, len(nodes))
+		for _, c := range nodes {
+			if visited[c.ID] {
+				continue
+			}
+			visited[c.ID] = true
+			node := dto.CategoryTreeNodeDto{
+				ID:                c.ID,
+				Name:              c.Name,
+				Slug:              c.Slug,
+				ParentID:          c.ParentID,
+				SortOrder:         c.SortOrder,
+				ArticleCount:      c.ArticleCount,
+				TotalArticleCount: c.ArticleCount,
+				Children:          build(childrenOf[c.ID]),
+			}
+			for _, child := range node.Children {
+				node.TotalArticleCount += child.TotalArticleCount
+			}
+			out = append(out, node)
+		}
+		return out
+	}
+	return build(roots)
+}
+
+// Reorder 一次更新多個分類的 parent 與 sortOrder（拖拉排序）。
+//
+// 先把所有變更套到記憶體中的完整分類圖再驗證，全部通過才在同一個 transaction 寫入：
+//   - id / parentId 必須存在，且 parentId 不可指向自己
+//   - 套用後不可形成循環（同 checkNoCycle，但以變更後的整體結構判斷）
+//   - 「未分類」必須維持頂層（DELETE 分類時文章 reassign 的落點）
+func (s *CategoryService) Reorder(req dto.ReorderCategoriesRequest) ([]dto.CategoryDto, error) {
+	var cats []models.Category
+	if err := s.db.Find(&cats).Error; err != nil {
+		return nil, err
+	}
+	byID := map[uint]*models.Category{}
+	for i := range cats {
+		byID[cats[i].ID] = &cats[i]
+	}
+
+	seen := map[uint]bool{}
+	for _, item := range req.Items {
+		if seen[item.ID] {
+			return nil, fmt.Errorf("%w: 分類 %d 重複出現", apierror.ErrBadRequest, item.ID)
+		}
+		seen[item.ID] = true
+
+		cat, ok := byID[item.ID]
+		if !ok {
+			return nil, fmt.Errorf("%w: 分類 %d 不存在", apierror.ErrNotFound, item.ID)
+		}
+		if item.ParentID != nil {
+			if *item.ParentID == item.ID {
+				return nil, fmt.Errorf("%w: 分類 %d 的 parentId 不可指向自己", apierror.ErrBadRequest, item.ID)
+			}
+			if _, ok := byID[*item.ParentID]; !ok {
+				return nil, fmt.Errorf("%w: parentId %d 不存在", apierror.ErrBadRequest, *item.ParentID)
+			}
+			if cat.Slug == UncategorizedSlug {
+				return nil, fmt.Errorf("%w: 「未分類」分類必須維持頂層", apierror.ErrForbidden)
+			}
+		}
+		cat.ParentID = item.ParentID
+		cat.SortOrder = item.SortOrder
+	}
+
+	// 變更後的整體結構：每個分類往上追，遇到自己即為循環
+	for _, cat := range cats {
+		current := cat.ParentID
+		for depth := 0; current != nil; depth++ {
+			if *current == cat.ID {
+				return nil, fmt.Errorf("%w: 分類 %d 的 parentId 會形成循環引用", apierror.ErrBadRequest, cat.ID)
+			}
+			if depth >= 100 {
+				return nil, fmt.Errorf("%w: 分類層級過深（>100）", apierror.ErrBadRequest)
+			}
+			parent, ok := byID[*current]
+			if !ok {
+				break // parent 已不存在，視為頂層
+			}
+			current = parent.ParentID
+		}
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		for _, item := range req.Items {
+			if err := tx.Model(&models.Category{}).Where("id = ?", item.ID).
+				UpdateColumns(map[string]any{
+					"parent_id":  item.ParentID,
+					"sort_order": item.SortOrder,
+				}).Error; err != nil {
+				return fmt.Errorf("更新分類 %d 失敗: %w", item.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(cats, func(i, j int) bool { return cats[i].SortOrder < cats[j].SortOrder })
+	out := make([]dto.CategoryDto, len(cats))
+	for i, cat := range cats {
+		out[i] = *mapCategoryToDto(cat)
+	}
+	return out, nil
+}
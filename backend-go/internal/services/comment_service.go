@@ -0,0 +1,375 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/paulhuang/paulfun-blogger/internal/apierror"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"gorm.io/gorm"
+)
+
+// maxCommentDepth 討論串巢狀層數上限（頂層留言為第 1 層）。
+const maxCommentDepth = 5
+
+// CommentSource 留言來源資訊（僅後台審核時可見）與垃圾訊息檢查。
+type CommentSource struct {
+	IP        string
+	UserAgent string
+	// Screen 垃圾訊息檢查，驗證通過後才呼叫；回傳 true 時直接存為 spam，不進審核佇列。nil 表示不檢查。
+	Screen func() bool
+}
+
+// CommentService 處理讀者留言與後台審核。
+type CommentService struct {
+	db *gorm.DB
+}
+
+func NewCommentService(db *gorm.DB) *CommentService {
+	return &CommentService{db: db}
+}
+
+// ListPublic 取得文章已公開的留言：頂層留言依時間分頁，回覆全部巢狀帶出。
+// 父留言未公開時，底下的回覆也不會出現。
+func (s *CommentService) ListPublic(articleID uint, q dto.CommentQueryParams) (dto.PagedResponse[dto.CommentDto], error) {
+	if err := s.ensureArticleVisible(articleID); err != nil {
+		return dto.PagedResponse[dto.CommentDto]{}, err
+	}
+
+	query := s.db.Model(&models.Comment{}).
+		Where("article_id = ? AND parent_id IS NULL AND status = ?", articleID, models.CommentStatusApproved)
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return dto.PagedResponse[dto.CommentDto]{}, err
+	}
+
+	page := q.GetPage()
+	pageSize := q.GetPageSize()
+
+	var rootIDs []uint
+	if err := query.Order("created_at ASC, id ASC").
+		Offset((page-1)*pageSize).Limit(pageSize).
+		Pluck("id", &rootIDs).Error; err != nil {
+		return dto.PagedResponse[dto.CommentDto]{}, err
+	}
+
+	var comments []models.Comment
+	if len(rootIDs) > 0 {
+		if err := s.db.Raw(`
+			WITH RECURSIVE thread AS (
+				SELECT * FROM comments WHERE id IN ?
+				UNION ALL
+				SELECT c.* FROM comments c
+				JOIN thread t ON c.parent_id = t.id
+				WHERE c.status = ?
+			)
+			SELECT * FROM thread`, rootIDs, models.CommentStatusApproved).Scan(&comments).Error; err != nil {
+			return dto.PagedResponse[dto.CommentDto]{}, err
+		}
+	}
+
+	totalPages := (int(totalCount) + pageSize - 1) / pageSize
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	return dto.PagedResponse[dto.CommentDto]{
+		Items:           buildCommentTree(comments, rootIDs),
+		TotalCount:      int(totalCount),
+		Page:            page,
+		PageSize:        pageSize,
+		TotalPages:      totalPages,
+		HasPreviousPage: page > 1,
+		HasNextPage:     page < totalPages,
+	}, nil
+}
+
+// Create 新增留言（進入審核佇列，狀態 pending；src.Screen 判定為 spam 時為 spam）。
+// 回覆時父留言須屬於同一篇文章且已公開，巢狀層數不可超過 maxCommentDepth。
+func (s *CommentService) Create(articleID uint, req dto.CreateCommentRequest, src CommentSource) (*dto.CommentDto, error) {
+	if err := s.ensureArticleVisible(articleID); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.AuthorName)
+	content := strings.TrimSpace(req.Content)
+	if name == "" || content == "" {
+		return nil, fmt.Errorf("%w: 名稱與內容不可為空白", apierror.ErrBadRequest)
+	}
+	var email *string
+	if req.AuthorEmail != nil && strings.TrimSpace(*req.AuthorEmail) != "" {
+		e := strings.TrimSpace(*req.AuthorEmail)
+		email = &e
+	}
+
+	if req.ParentID != nil {
+		if err := s.checkReplyTarget(articleID, *req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	comment := models.Comment{
+		ArticleID:   articleID,
+		ParentID:    req.ParentID,
+		AuthorName:  name,
+		AuthorEmail: email,
+		Content:     content,
+		Status:      models.CommentStatusPending,
+		IPAddress:   src.IP,
+		UserAgent:   truncateRunes(src.UserAgent, 299), // 欄位 300 字，含截斷符號
+	}
+	if src.Screen != nil && src.Screen() {
+		comment.Status = models.CommentStatusSpam
+	}
+	if err := s.db.Create(&comment).Error; err != nil {
+		return nil, err
+	}
+
+	// 被判定為 spam 時仍回 pending，不讓機器人得知判定結果
+	d := mapCommentDto(comment)
+	d.Status = models.CommentStatusPending
+	return &d, nil
+}
+
+// checkReplyTarget 驗證回覆對象並檢查巢狀層數。
+func (s *CommentService) checkReplyTarget(articleID, parentID uint) error {
+	var parent models.Comment
+	if err := s.db.Select("id", "article_id", "parent_id", "status").First(&parent, parentID).Error; err != nil ||
+		parent.ArticleID != articleID || parent.Status != models.CommentStatusApproved {
+		return fmt.Errorf("%w: 回覆的留言不存在", apierror.ErrBadRequest)
+	}
+
+	// 新回覆的層數 = 父留言層數 + 1
+	depth := 2
+	for cur := parent.ParentID; cur != nil && depth <= maxCommentDepth; depth++ {
+		var p models.Comment
+		if err := s.db.Select("id", "parent_id").First(&p, *cur).Error; err != nil {
+			break
+		}
+		cur = p.ParentID
+	}
+	if depth > maxCommentDepth {
+		return fmt.Errorf("%w: 回覆層數最多 %d 層", apierror.ErrBadRequest, maxCommentDepth)
+	}
+	return nil
+}
+
+// ListForModeration 後台審核佇列。status=all 時不限狀態。
+func (s *CommentService) ListForModeration(q dto.AdminCommentQueryParams) (dto.PagedResponse[dto.AdminCommentDto], error) {
+	status := q.GetStatus()
+	query := s.db.Model(&models.Comment{})
+	if status != "all" {
+		if !isCommentStatus(status) {
+			return dto.PagedResponse[dto.AdminCommentDto]{}, fmt.Errorf("%w: status 僅接受 pending / approved / rejected / spam / all", apierror.ErrBadRequest)
+		}
+		query = query.Where("status = ?", status)
+	}
+	if q.ArticleID != 0 {
+		query = query.Where("article_id = ?", q.ArticleID)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return dto.PagedResponse[dto.AdminCommentDto]{}, err
+	}
+
+	page := q.GetPage()
+	pageSize := q.GetPageSize()
+
+	var comments []models.Comment
+	if err := query.Order("created_at DESC, id DESC").
+		Offset((page - 1) * pageSize).Limit(pageSize).
+		Find(&comments).Error; err != nil {
+		return dto.PagedResponse[dto.AdminCommentDto]{}, err
+	}
+
+	titles, err := s.articleTitles(comments)
+	if err != nil {
+		return dto.PagedResponse[dto.AdminCommentDto]{}, err
+	}
+	items := make([]dto.AdminCommentDto, len(comments))
+	for i, c := range comments {
+		items[i] = mapAdminCommentDto(c, titles[c.ArticleID])
+	}
+
+	totalPages := (int(totalCount) + pageSize - 1) / pageSize
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	return dto.PagedResponse[dto.AdminCommentDto]{
+		Items:           items,
+		TotalCount:      int(totalCount),
+		Page:            page,
+		PageSize:        pageSize,
+		TotalPages:      totalPages,
+		HasPreviousPage: page > 1,
+		HasNextPage:     page < totalPages,
+	}, nil
+}
+
+// Moderate 變更留言狀態（approved / rejected / spam），記錄審核者與時間。
+func (s *CommentService) Moderate(id uint, status string, userID uint) (*dto.AdminCommentDto, error) {
+	if !isCommentStatus(status) || status == models.CommentStatusPending {
+		return nil, fmt.Errorf("%w: 不支援的審核狀態 %q", apierror.ErrBadRequest, status)
+	}
+
+	var comment models.Comment
+	if err := s.db.First(&comment, id).Error; err != nil {
+		return nil, notFoundOr(err, "留言", fmt.Sprint(id))
+	}
+
+	now := time.Now().UTC()
+	if err := s.db.Model(&comment).Updates(map[string]any{
+		"status":       status,
+		"moderated_at": now,
+		"moderated_by": userID,
+	}).Error; err != nil {
+		return nil, err
+	}
+	comment.Status = status
+	comment.ModeratedAt = &now
+	comment.ModeratedBy = &userID
+
+	titles, err := s.articleTitles([]models.Comment{comment})
+	if err != nil {
+		return nil, err
+	}
+	d := mapAdminCommentDto(comment, titles[comment.ArticleID])
+	return &d, nil
+}
+
+// Delete 永久刪除留言與其底下所有回覆，回傳被刪除的 ID。
+func (s *CommentService) Delete(id uint) ([]uint, error) {
+	var deleted []uint
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw(`
+			WITH RECURSIVE thread AS (
+				SELECT id FROM comments WHERE id = ?
+				UNION ALL
+				SELECT c.id FROM comments c JOIN thread t ON c.parent_id = t.id
+			)
+			SELECT id FROM thread`, id).Scan(&deleted).Error; err != nil {
+			return err
+		}
+		if len(deleted) == 0 {
+			return fmt.Errorf("%w: 留言 %d 不存在", apierror.ErrNotFound, id)
+		}
+		return tx.Where("id IN ?", deleted).Delete(&models.Comment{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(deleted, func(i, j int) bool { return deleted[i] < deleted[j] })
+	return deleted, nil
+}
+
+// ensureArticleVisible 文章須已發佈且 published_at 已到；否則一律 404，不洩漏草稿存在性。
+func (s *CommentService) ensureArticleVisible(articleID uint) error {
+	var count int64
+	if err := s.db.Model(&models.Article{}).
+		Where("id = ? AND status = ? AND published_at <= ?", articleID, "published", time.Now().UTC()).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return apierror.ErrNotFound
+	}
+	return nil
+}
+
+// articleTitles 查詢留言所屬文章的標題（含已在垃圾桶中的文章）。
+func (s *CommentService) articleTitles(comments []models.Comment) (map[uint]string, error) {
+	ids := make([]uint, 0, len(comments))
+	for _, c := range comments {
+		ids = append(ids, c.ArticleID)
+	}
+	titles := map[uint]string{}
+	if len(ids) == 0 {
+		return titles, nil
+	}
+	var articles []models.Article
+	if err := s.db.Unscoped().Select("id", "title").Where("id IN ?", uniqueUints(ids)).Find(&articles).Error; err != nil {
+		return nil, err
+	}
+	for _, a := range articles {
+		titles[a.ID] = a.Title
+	}
+	return titles, nil
+}
+
+func isCommentStatus(status string) bool {
+	switch status {
+	case models.CommentStatusPending, models.CommentStatusApproved,
+		models.CommentStatusRejected, models.CommentStatusSpam:
+		return true
+	}
+	return false
+}
+
+// buildCommentTree 依 parent_id 組成巢狀結構；rootIDs 決定頂層順序，回覆依時間排序。
+func buildCommentTree(comments []models.Comment, rootIDs []uint) []dto.CommentDto {
+	byID := make(map[uint]models.Comment, len(comments))
+	children := map[uint][]models.Comment{}
+	for _, c := range comments {
+		byID[c.ID] = c
+		if c.ParentID != nil {
+			children[*c.ParentID] = append(children[*c.ParentID], c)
+		}
+	}
+
+	var build func(c models.Comment) dto.CommentDto
+	build = func(c models.Comment) dto.CommentDto {
+		d := mapCommentDto(c)
+		kids := children[c.ID]
+		sort.Slice(kids, func(i, j int) bool {
+			if !kids[i].CreatedAt.Equal(kids[j].CreatedAt) {
+				return kids[i].CreatedAt.Before(kids[j].CreatedAt)
+			}
+			return kids[i].ID < kids[j].ID
+		})
+		for _, k := range kids {
+			d.Replies = append(d.Replies, build(k))
+		}
+		return d
+	}
+
+	out := make([]dto.CommentDto, 0, len(rootIDs))
+	for _, id := range rootIDs {
+		if c, ok := byID[id]; ok {
+			out = append(out, build(c))
+		}
+	}
+	return out
+}
+
+func mapCommentDto(c models.Comment) dto.CommentDto {
+	return dto.CommentDto{
+		ID:         c.ID,
+		ParentID:   c.ParentID,
+		AuthorName: c.AuthorName,
+		Content:    c.Content,
+		CreatedAt:  c.CreatedAt,
+		Replies:    []dto.CommentDto{},
+	}
+}
+
+func mapAdminCommentDto(c models.Comment, articleTitle string) dto.AdminCommentDto {
+	return dto.AdminCommentDto{
+		ID:           c.ID,
+		ArticleID:    c.ArticleID,
+		ArticleTitle: articleTitle,
+		ParentID:     c.ParentID,
+		AuthorName:   c.AuthorName,
+		AuthorEmail:  c.AuthorEmail,
+		Content:      c.Content,
+		Status:       c.Status,
+		IPAddress:    c.IPAddress,
+		UserAgent:    c.UserAgent,
+		CreatedAt:    c.CreatedAt,
+		ModeratedAt:  c.ModeratedAt,
+		ModeratedBy:  c.ModeratedBy,
+	}
+}
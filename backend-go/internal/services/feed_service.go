@@ -0,0 +1,169 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/paulhuang/paulfun-blogger/internal/apierror"
+	"github.com/paulhuang/paulfun-blogger/internal/feed"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	feedSiteTitle       = "PaulFun Blogger"
+	feedSiteDescription = "Paul 的個人部落格 — 技術筆記、生活隨筆、學習心得分享"
+	feedLanguage        = "zh-TW"
+	feedItemLimit       = 20
+	feedSummaryRunes    = 200 // 沒有 summary 時從內文截取的長度
+)
+
+// FeedQuery 訂閱 feed 的篩選條件。
+type FeedQuery struct {
+	Category string // 分類 slug（空 = 全站）
+	Tag      string // 標籤 slug（空 = 不限）
+	Full     bool   // true 輸出全文；false 只輸出摘要
+}
+
+// FeedService 產生 RSS / Atom / JSON Feed 的內容。
+type FeedService struct {
+	db      *gorm.DB
+	baseURL string
+
+	mu        sync.Mutex
+	changedAt time.Time // 最近一次前台內容異動（或啟動）時間，只會往前
+}
+
+func NewFeedService(db *gorm.DB, baseURL string) *FeedService {
+	return &FeedService{db: db, baseURL: strings.TrimRight(baseURL, "/"), changedAt: time.Now().UTC()}
+}
+
+// MarkChanged 記錄前台內容異動（ArticleService.OnPublicChange 呼叫）。
+func (s *FeedService) MarkChanged() {
+	s.mu.Lock()
+	s.changedAt = time.Now().UTC()
+	s.mu.Unlock()
+}
+
+// LastModified HTTP Last-Modified：文章最新更新時間與最近一次異動取較新者。
+// 只看文章時間會在最新一篇下架 / 刪除後倒退，只帶 If-Modified-Since 的客戶端會拿到 304 而留著已移除的項目。
+func (s *FeedService) LastModified(f *feed.Feed) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f.Updated.After(s.changedAt) {
+		return f.Updated
+	}
+	return s.changedAt
+}
+
+// URL 把站內路徑轉成絕對網址。
+func (s *FeedService) URL(path string) string {
+	return s.baseURL + path
+}
+
+// Load 查詢最新 feedItemLimit 篇已發佈文章（排程中、published_at 未到者不列入）。
+// 分類 / 標籤 slug 不存在時回 ErrNotFound。
+func (s *FeedService) Load(q FeedQuery) (*feed.Feed, error) {
+	f := &feed.Feed{
+		Title:       feedSiteTitle,
+		Description: feedSiteDescription,
+		Link:        s.URL("/"),
+		Language:    feedLanguage,
+	}
+
+	query := s.db.Model(&models.Article{}).
+		Preload("Author").
+		Preload("Category").
+		Preload("Tags").
+		Where("status = ? AND published_at <= ?", "published", time.Now().UTC())
+
+	if q.Category != "" {
+		var cat models.Category
+		if err := s.db.Where("slug = ?", q.Category).First(&cat).Error; err != nil {
+			return nil, notFoundOr(err, "分類", q.Category)
+		}
+		query = query.Where("category_id = ?", cat.ID)
+		f.Title += " — " + cat.Name
+		f.Link = s.URL("/categories/" + cat.Slug)
+	}
+	if q.Tag != "" {
+		var tag models.Tag
+		if err := s.db.Where("slug = ?", q.Tag).First(&tag).Error; err != nil {
+			return nil, notFoundOr(err, "標籤", q.Tag)
+		}
+		query = query.Where("articles.id IN (SELECT article_id FROM article_tags WHERE tag_id = ?)", tag.ID)
+		f.Title += " — #" + tag.Name
+		f.Link = s.URL("/tags/" + tag.Slug)
+	}
+
+	var articles []models.Article
+	if err := query.Order("published_at DESC").Limit(feedItemLimit).Find(&articles).Error; err != nil {
+		return nil, err
+	}
+
+	f.Items = make([]feed.Item, len(articles))
+	for i, a := range articles {
+		item := s.mapItem(a, q.Full)
+		if item.Updated.After(f.Updated) {
+			f.Updated = item.Updated
+		}
+		f.Items[i] = item
+	}
+	return f, nil
+}
+
+func (s *FeedService) mapItem(a models.Article, full bool) feed.Item {
+	link := s.URL(fmt.Sprintf("/articles/%d", a.ID))
+	published := *a.PublishedAt
+	updated := published
+	if a.UpdatedAt != nil && a.UpdatedAt.After(updated) {
+		updated = *a.UpdatedAt
+	}
+
+	var categories []string
+	if a.Category != nil {
+		categories = append(categories, a.Category.Name)
+	}
+	for _, t := range a.Tags {
+		categories = append(categories, t.Name)
+	}
+
+	item := feed.Item{
+		ID:         link,
+		Title:      a.Title,
+		Link:       link,
+		Summary:    articleSummary(a),
+		Author:     a.Author.DisplayName,
+		Categories: categories,
+		Published:  published,
+		Updated:    updated,
+	}
+	if full && a.Content != nil {
+		item.Content = *a.Content
+	}
+	return item
+}
+
+// articleSummary 優先用 summary；沒有時從內文（去 HTML）截取開頭。
+func articleSummary(a models.Article) string {
+	if a.Summary != nil && strings.TrimSpace(*a.Summary) != "" {
+		return *a.Summary
+	}
+	if a.Content == nil {
+		return ""
+	}
+	plain := html.UnescapeString(htmlTagRe.ReplaceAllString(*a.Content, " "))
+	return truncateRunes(strings.Join(strings.Fields(plain), " "), feedSummaryRunes)
+}
+
+// notFoundOr 查無資料時轉成帶名稱的 ErrNotFound，其餘錯誤原樣回傳。
+func notFoundOr(err error, kind, slug string) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: %s %q 不存在", apierror.ErrNotFound, kind, slug)
+	}
+	return err
+}
@@ -184,12 +184,7 @@ func (s *ImportService) importOneArticle(
 
 	// 確保 slug 唯一（生成衝突時加後綴）
 	uniqueSlug := slug
-	for i := 1; ; i++ {
-		var cnt int64
-		s.db.Model(&models.Article{}).Where("slug = ?", uniqueSlug).Count(&cnt)
-		if cnt == 0 {
-			break
-		}
+	for i := 1; slugTaken(s.db, uniqueSlug); i++ {
 		uniqueSlug = fmt.Sprintf("%s-%d", slug, i)
 	}
 
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/paulhuang/paulfun-blogger/internal/apierror"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// rankingDepth 每個排行快取的文章數（多於公開 API 的 limit 上限，扣掉已下架的仍夠用）。
+	rankingDepth = 100
+	// trendingLookbackDays 趨勢分數只看最近這幾天的瀏覽與按讚。
+	trendingLookbackDays = 14
+
+	RankingTrending = "trending"
+)
+
+// popularWindows 熱門文章的時間窗（天數，0 = 全部，直接用 articles.view_count）。
+var popularWindows = map[string]int{"7d": 7, "30d": 30, "all": 0}
+
+// RankingSettings 排行設定（來自 Config）。
+type RankingSettings struct {
+	RefreshInterval time.Duration // 快取有效期（背景 job 以 JobInterval 重算，比有效期短）
+	HalfLife        time.Duration // 趨勢分數的半衰期
+	LikeWeight      float64       // 一個讚相當於幾次瀏覽
+}
+
+type rankedID struct {
+	ID    uint
+	Score float64
+}
+
+type ranking struct {
+	builtAt time.Time
+	items   []rankedID
+}
+
+// rankingCall 進行中的一次重算；同一排行同時只有一個，其他請求等 done 後共用結果。
+type rankingCall struct {
+	done chan struct{}
+	r    *ranking
+	err  error
+}
+
+// RankingService 熱門（區間瀏覽數）與趨勢（時間衰減的瀏覽 + 按讚）文章排行。
+//
+// 快取只存排序後的文章 ID 與分數；回傳前再以「已發佈且 published_at 已到」重新篩選並載入文章，
+// 因此文章下架、刪除後立即不再出現。新發佈的文章要等下一次重算才會進榜。
+type RankingService struct {
+	db       *gorm.DB
+	settings RankingSettings
+
+	mu       sync.Mutex
+	cache    map[string]*ranking
+	inflight map[string]*rankingCall
+}
+
+func NewRankingService(db *gorm.DB, settings RankingSettings) *RankingService {
+	if settings.RefreshInterval <= 0 {
+		settings.RefreshInterval = 10 * time.Minute
+	}
+	if settings.HalfLife <= 0 {
+		settings.HalfLife = 48 * time.Hour
+	}
+	return &RankingService{
+		db:       db,
+		settings: settings,
+		cache:    make(map[string]*ranking),
+		inflight: make(map[string]*rankingCall),
+	}
+}
+
+// JobInterval 背景 job 的重算間隔：有效期的一半，快取在到期前就會被換新，請求不必等重算。
+func (s *RankingService) JobInterval() time.Duration {
+	return s.settings.RefreshInterval / 2
+}
+
+// Refresh 重算所有排行（背景 job 呼叫），回傳重算的排行數。
+func (s *RankingService) Refresh() (int, error) {
+	keys := []string{RankingTrending}
+	for w := range popularWindows {
+		keys = append(keys, w)
+	}
+	for _, key := range keys {
+		s.mu.Lock()
+		call, leader := s.beginLocked(key)
+		s.mu.Unlock()
+		if leader {
+			s.run(key, call)
+		}
+		<-call.done
+		if call.err != nil {
+			return 0, fmt.Errorf("排行 %s: %w", key, call.err)
+		}
+	}
+	return len(keys), nil
+}
+
+// Popular 熱門文章：window 內瀏覽數最多（all = 累計瀏覽數）。
+func (s *RankingService) Popular(q dto.PopularQueryParams) (*dto.ArticleRankingDto, error) {
+	window := q.Window
+	if window == "" {
+		window = "7d"
+	}
+	if _, ok := popularWindows[window]; !ok {
+		return nil, fmt.Errorf("%w: window 僅接受 7d / 30d / all", apierror.ErrBadRequest)
+	}
+	return s.get(window, q.GetLimit())
+}
+
+// Trending 趨勢文章：最近瀏覽與按讚依半衰期衰減後加總。
+func (s *RankingService) Trending(q dto.TrendingQueryParams) (*dto.ArticleRankingDto, error) {
+	return s.get(RankingTrending, q.GetLimit())
+}
+
+func (s *RankingService) get(key string, limit int) (*dto.ArticleRankingDto, error) {
+	r, err := s.cached(key)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, len(r.items))
+	for i, it := range r.items {
+		ids[i] = it.ID
+	}
+	var articles []models.Article
+	if len(ids) > 0 {
+		if err := s.db.Preload("Author").Preload("Category").Preload("Tags").
+			Where("id IN ? AND status = ? AND published_at <= ?", ids, "published", time.Now().UTC()).
+			Find(&articles).Error; err != nil {
+			return nil, err
+		}
+	}
+	byID := make(map[uint]models.Article, len(articles))
+	for _, a := range articles {
+		byID[a.ID] = a
+	}
+
+	result := &dto.ArticleRankingDto{Window: key, GeneratedAt: r.builtAt, Items: []dto.RankedArticleDto{}}
+	for _, it := range r.items {
+		a, ok := byID[it.ID]
+		if !ok {
+			continue
+		}
+		result.Items = append(result.Items, dto.RankedArticleDto{ArticleListItemDto: mapToListItemDto(a), Score: it.Score})
+		if len(result.Items) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// cached 取得快取。超過 RefreshInterval 時先回舊排行，由第一個請求在背景重算；
+// 完全沒有快取（剛啟動）時同一排行只重算一次，其他請求等它的結果。
+func (s *RankingService) cached(key string) (*ranking, error) {
+	s.mu.Lock()
+	r := s.cache[key]
+	if r != nil && time.Since(r.builtAt) < s.settings.RefreshInterval {
+		s.mu.Unlock()
+		return r, nil
+	}
+	call, leader := s.beginLocked(key)
+	s.mu.Unlock()
+
+	if r != nil {
+		if leader {
+			go func() {
+				if err := s.run(key, call); err != nil {
+					log.Printf("[ranking] 重算 %s 失敗: %v", key, err)
+				}
+			}()
+		}
+		return r, nil
+	}
+	if leader {
+		s.run(key, call)
+	}
+	<-call.done
+	return call.r, call.err
+}
+
+// beginLocked 取得 key 進行中的重算；沒有時建立一個，leader=true 表示由呼叫端負責執行 run。
+// 呼叫前須持有 s.mu。
+func (s *RankingService) beginLocked(key string) (call *rankingCall, leader bool) {
+	if call := s.inflight[key]; call != nil {
+		return call, false
+	}
+	call = &rankingCall{done: make(chan struct{})}
+	s.inflight[key] = call
+	return call, true
+}
+
+// run 在鎖外重算，完成後寫入快取（並行時保留較新的結果）並喚醒等待者。
+func (s *RankingService) run(key string, call *rankingCall) error {
+	r, err := s.compute(key)
+
+	s.mu.Lock()
+	if err == nil {
+		if cur := s.cache[key]; cur != nil && cur.builtAt.After(r.builtAt) {
+			r = cur
+		}
+		s.cache[key] = r
+	}
+	call.r, call.err = r, err
+	delete(s.inflight, key)
+	s.mu.Unlock()
+
+	close(call.done)
+	return err
+}
+
+// compute 以 SQL 算出前 rankingDepth 名。只計入目前可公開的文章，分數為 0 的不列入。
+func (s *RankingService) compute(key string) (*ranking, error) {
+	now := time.Now().UTC()
+	const visible = "a.status = 'published' AND a.published_at <= ? AND a.deleted_at IS NULL"
+
+	var items []rankedID
+	var err error
+	switch days, popular := popularWindows[key]; {
+	case popular && days == 0:
+		err = s.db.Raw(`
+			SELECT a.id, a.view_count AS score
+			FROM articles a
+			WHERE `+visible+` AND a.view_count > 0
+			ORDER BY a.view_count DESC, a.published_at DESC
+			LIMIT ?`, now, rankingDepth).Scan(&items).Error
+	case popular:
+		err = s.db.Raw(`
+			SELECT a.id, SUM(st.views) AS score
+			FROM article_stats st
+			JOIN articles a ON a.id = st.article_id
+			WHERE `+visible+` AND st.day > ?::date - ?::int
+			GROUP BY a.id, a.published_at
+			ORDER BY score DESC, a.published_at DESC
+			LIMIT ?`, now, now.Format("2006-01-02"), days, rankingDepth).Scan(&items).Error
+	case key == RankingTrending:
+		// 每日瀏覽以「距今天數」衰減，按讚以「距今小時數」衰減；半衰期以小時計
+		halfLifeHours := s.settings.HalfLife.Hours()
+		err = s.db.Raw(`
+			WITH v AS (
+				SELECT article_id, SUM(views * POWER(0.5, (?::date - day) * 24.0 / ?)) AS score
+				FROM article_stats
+				WHERE day > ?::date - ?::int
+				GROUP BY article_id
+			), l AS (
+				SELECT article_id, SUM(POWER(0.5, EXTRACT(EPOCH FROM (?::timestamptz - created_at)) / 3600.0 / ?)) AS score
+				FROM article_likes
+				WHERE created_at > ?::timestamptz - make_interval(days => ?)
+				GROUP BY article_id
+			)
+			SELECT a.id, COALESCE(v.score, 0) + ? * COALESCE(l.score, 0) AS score
+			FROM articles a
+			LEFT JOIN v ON v.article_id = a.id
+			LEFT JOIN l ON l.article_id = a.id
+			WHERE `+visible+` AND (v.article_id IS NOT NULL OR l.article_id IS NOT NULL)
+			ORDER BY score DESC, a.published_at DESC
+			LIMIT ?`,
+			now.Format("2006-01-02"), halfLifeHours, now.Format("2006-01-02"), trendingLookbackDays,
+			now, halfLifeHours, now, trendingLookbackDays,
+			s.settings.LikeWeight, now, rankingDepth).Scan(&items).Error
+	default:
+		return nil, fmt.Errorf("%w: 未知的排行 %q", apierror.ErrBadRequest, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ranking{builtAt: time.Now(), items: items}, nil
+}
@@ -0,0 +1,403 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/paulhuang/paulfun-blogger/internal/apierror"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// seriesMemberTrashed 後台系列明細中，成員文章在垃圾桶時的 status。
+const seriesMemberTrashed = "trashed"
+
+// SeriesService 文章系列（前台瀏覽 + 後台管理成員與順序）。
+type SeriesService struct {
+	db *gorm.DB
+}
+
+func NewSeriesService(db *gorm.DB) *SeriesService {
+	return &SeriesService{db: db}
+}
+
+// ListPublic 有已發佈文章的系列，依最近一篇發佈時間新 → 舊。
+func (s *SeriesService) ListPublic() ([]dto.SeriesDto, error) {
+	var rows []dto.SeriesDto
+	if err := s.db.Raw(`
+		SELECT s.id, s.title, s.slug, s.description, s.cover_image, s.created_at, s.updated_at,
+		       COUNT(a.id) AS article_count
+		FROM series s
+		JOIN series_articles sa ON sa.series_id = s.id
+		JOIN articles a ON a.id = sa.article_id
+		WHERE a.status = 'published' AND a.published_at <= ? AND a.deleted_at IS NULL
+		GROUP BY s.id
+		ORDER BY MAX(a.published_at) DESC, s.id DESC
+	`, time.Now().UTC()).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if rows == nil {
+		rows = []dto.SeriesDto{}
+	}
+	return rows, nil
+}
+
+// GetPublicBySlug 系列與其已發佈文章（依順序）。沒有任何已發佈文章的系列視為不存在。
+func (s *SeriesService) GetPublicBySlug(slug string) (*dto.SeriesDetailDto, error) {
+	var series models.Series
+	if err := s.db.Where("slug = ?", slug).First(&series).Error; err != nil {
+		return nil, fmt.Errorf("%w: 系列不存在", apierror.ErrNotFound)
+	}
+	members, err := seriesMembers(s.db, series.ID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	articles := make([]dto.SeriesArticleDto, 0, len(members))
+	for _, m := range members {
+		if !isPubliclyVisible(m.Article, now) {
+			continue
+		}
+		d := mapSeriesArticleDto(m, len(articles)+1)
+		d.Status = ""
+		articles = append(articles, d)
+	}
+	if len(articles) == 0 {
+		return nil, fmt.Errorf("%w: 系列不存在", apierror.ErrNotFound)
+	}
+	return &dto.SeriesDetailDto{SeriesDto: mapSeriesDto(series, len(articles)), Articles: articles}, nil
+}
+
+// List 後台：所有系列（含沒有成員的），ArticleCount 不計垃圾桶中的文章。
+func (s *SeriesService) List() ([]dto.SeriesDto, error) {
+	var rows []dto.SeriesDto
+	if err := s.db.Raw(`
+		SELECT s.id, s.title, s.slug, s.description, s.cover_image, s.created_at, s.updated_at,
+		       COUNT(a.id) AS article_count
+		FROM series s
+		LEFT JOIN series_articles sa ON sa.series_id = s.id
+		LEFT JOIN articles a ON a.id = sa.article_id AND a.deleted_at IS NULL
+		GROUP BY s.id
+		ORDER BY s.updated_at DESC, s.id DESC
+	`).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if rows == nil {
+		rows = []dto.SeriesDto{}
+	}
+	return rows, nil
+}
+
+// Get 後台：系列與所有成員（含草稿、排程與垃圾桶中的文章）。
+func (s *SeriesService) Get(id uint) (*dto.SeriesDetailDto, error) {
+	series, err := s.find(s.db, id)
+	if err != nil {
+		return nil, err
+	}
+	members, err := seriesMembers(s.db, id, true)
+	if err != nil {
+		return nil, err
+	}
+	articles := make([]dto.SeriesArticleDto, len(members))
+	count := 0
+	for i, m := range members {
+		articles[i] = mapSeriesArticleDto(m, i+1)
+		if !m.Article.DeletedAt.Valid {
+			count++
+		}
+	}
+	return &dto.SeriesDetailDto{SeriesDto: mapSeriesDto(*series, count), Articles: articles}, nil
+}
+
+// Create 建立系列（尚無成員）。
+func (s *SeriesService) Create(req dto.CreateSeriesRequest) (*dto.SeriesDetailDto, error) {
+	slug := req.Slug
+	if slug == "" {
+		slug = generateSlug(req.Title)
+	}
+	if err := s.checkSlug(slug, 0); err != nil {
+		return nil, err
+	}
+
+	series := models.Series{
+		Title:       req.Title,
+		Slug:        slug,
+		Description: req.Description,
+		CoverImage:  req.CoverImage,
+	}
+	if err := s.db.Create(&series).Error; err != nil {
+		return nil, fmt.Errorf("建立系列失敗: %w", err)
+	}
+	return &dto.SeriesDetailDto{SeriesDto: mapSeriesDto(series, 0), Articles: []dto.SeriesArticleDto{}}, nil
+}
+
+// Update 修改標題、slug、描述與封面（全欄位替換語義）。
+func (s *SeriesService) Update(id uint, req dto.UpdateSeriesRequest) (*dto.SeriesDetailDto, error) {
+	series, err := s.find(s.db, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Slug != series.Slug {
+		if err := s.checkSlug(req.Slug, id); err != nil {
+			return nil, err
+		}
+	}
+
+	series.Title = req.Title
+	series.Slug = req.Slug
+	series.Description = req.Description
+	series.CoverImage = req.CoverImage
+	if err := s.db.Save(series).Error; err != nil {
+		return nil, fmt.Errorf("更新系列失敗: %w", err)
+	}
+	return s.Get(id)
+}
+
+// Delete 刪除系列與成員關聯（文章本身不受影響）。
+func (s *SeriesService) Delete(id uint) error {
+	series, err := s.find(s.db, id)
+	if err != nil {
+		return err
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("series_id = ?", id).Delete(&models.SeriesArticle{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(series).Error
+	})
+}
+
+// AddArticle 把文章放進系列的指定位置（已在本系列時視為移動並更新 note）。
+// 一篇文章只能屬於一個系列，已在其他系列時回 409。
+func (s *SeriesService) AddArticle(seriesID uint, req dto.AddSeriesArticleRequest) (*dto.SeriesDetailDto, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		series, err := s.lock(tx, seriesID)
+		if err != nil {
+			return err
+		}
+
+		var article models.Article
+		if err := tx.Select("id").First(&article, req.ArticleID).Error; err != nil {
+			return fmt.Errorf("%w: 文章不存在", apierror.ErrNotFound)
+		}
+
+		var current models.SeriesArticle
+		err = tx.Where("article_id = ?", req.ArticleID).First(&current).Error
+		isMember := err == nil
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if isMember && current.SeriesID != seriesID {
+			var other models.Series
+			tx.Select("title").First(&other, current.SeriesID)
+			return fmt.Errorf("%w: 文章已屬於系列「%s」", apierror.ErrConflict, other.Title)
+		}
+
+		order, err := memberOrder(tx, seriesID)
+		if err != nil {
+			return err
+		}
+		order = removeUint(order, req.ArticleID)
+		pos := req.Position
+		if pos < 1 || pos > len(order)+1 {
+			pos = len(order) + 1
+		}
+		order = append(order[:pos-1], append([]uint{req.ArticleID}, order[pos-1:]...)...)
+
+		if isMember {
+			if err := tx.Model(&current).Update("note", req.Note).Error; err != nil {
+				return err
+			}
+		} else if err := tx.Create(&models.SeriesArticle{
+			SeriesID:  seriesID,
+			ArticleID: req.ArticleID,
+			Position:  pos,
+			Note:      req.Note,
+		}).Error; err != nil {
+			return err
+		}
+		return renumberSeries(tx, series, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(seriesID)
+}
+
+// RemoveArticle 把文章移出系列，其後的文章往前補位。
+func (s *SeriesService) RemoveArticle(seriesID, articleID uint) (*dto.SeriesDetailDto, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		series, err := s.lock(tx, seriesID)
+		if err != nil {
+			return err
+		}
+		res := tx.Where("series_id = ? AND article_id = ?", seriesID, articleID).Delete(&models.SeriesArticle{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("%w: 文章不在此系列", apierror.ErrNotFound)
+		}
+		order, err := memberOrder(tx, seriesID)
+		if err != nil {
+			return err
+		}
+		return renumberSeries(tx, series, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(seriesID)
+}
+
+// Reorder 依 articleIds 重新排列；必須恰好包含所有成員（含垃圾桶中的文章）。
+func (s *SeriesService) Reorder(seriesID uint, req dto.ReorderSeriesRequest) (*dto.SeriesDetailDto, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		series, err := s.lock(tx, seriesID)
+		if err != nil {
+			return err
+		}
+		current, err := memberOrder(tx, seriesID)
+		if err != nil {
+			return err
+		}
+		order := uniqueUints(req.ArticleIDs)
+		if len(order) != len(req.ArticleIDs) {
+			return fmt.Errorf("%w: articleIds 不可重複", apierror.ErrBadRequest)
+		}
+		members := make(map[uint]bool, len(current))
+		for _, id := range current {
+			members[id] = true
+		}
+		if len(order) != len(current) {
+			return fmt.Errorf("%w: articleIds 須包含系列全部 %d 篇文章", apierror.ErrBadRequest, len(current))
+		}
+		for _, id := range order {
+			if !members[id] {
+				return fmt.Errorf("%w: 文章 %d 不在此系列", apierror.ErrBadRequest, id)
+			}
+		}
+		return renumberSeries(tx, series, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(seriesID)
+}
+
+// ── 內部 helpers ──────────────────────────────────────────────────────────
+
+func (s *SeriesService) find(db *gorm.DB, id uint) (*models.Series, error) {
+	var series models.Series
+	if err := db.First(&series, id).Error; err != nil {
+		return nil, fmt.Errorf("%w: 系列不存在", apierror.ErrNotFound)
+	}
+	return &series, nil
+}
+
+// lock 鎖住系列（FOR UPDATE），讓同一系列的成員異動依序執行。
+func (s *SeriesService) lock(tx *gorm.DB, id uint) (*models.Series, error) {
+	return s.find(tx.Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (s *SeriesService) checkSlug(slug string, excludeID uint) error {
+	var cnt int64
+	if err := s.db.Model(&models.Series{}).Where("slug = ? AND id <> ?", slug, excludeID).Count(&cnt).Error; err != nil {
+		return err
+	}
+	if cnt > 0 {
+		return fmt.Errorf("%w: slug %q 已被使用", apierror.ErrConflict, slug)
+	}
+	return nil
+}
+
+// seriesMembers 依順序載入成員與文章；includeTrashed 時含垃圾桶中的文章，否則略過。
+func seriesMembers(db *gorm.DB, seriesID uint, includeTrashed bool) ([]models.SeriesArticle, error) {
+	var members []models.SeriesArticle
+	if err := db.Preload("Article", func(q *gorm.DB) *gorm.DB {
+		q = q.Select("id", "title", "slug", "summary", "status", "published_at", "deleted_at")
+		if includeTrashed {
+			q = q.Unscoped()
+		}
+		return q
+	}).Where("series_id = ?", seriesID).Order("position, article_id").Find(&members).Error; err != nil {
+		return nil, err
+	}
+	out := members[:0]
+	for _, m := range members {
+		if m.Article.ID != 0 {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+// memberOrder 系列目前成員的文章 ID（依順序）。
+func memberOrder(tx *gorm.DB, seriesID uint) ([]uint, error) {
+	var ids []uint
+	err := tx.Model(&models.SeriesArticle{}).Where("series_id = ?", seriesID).
+		Order("position, article_id").Pluck("article_id", &ids).Error
+	return ids, err
+}
+
+// renumberSeries 依 order 重新編號為 1..n，並更新系列的 updated_at。
+func renumberSeries(tx *gorm.DB, series *models.Series, order []uint) error {
+	for i, id := range order {
+		if err := tx.Model(&models.SeriesArticle{}).
+			Where("series_id = ? AND article_id = ?", series.ID, id).
+			Update("position", i+1).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Model(series).Update("updated_at", time.Now()).Error
+}
+
+func removeUint(ids []uint, target uint) []uint {
+	out := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id != target {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// isPubliclyVisible 已發佈、published_at 已到且不在垃圾桶。
+func isPubliclyVisible(a models.Article, now time.Time) bool {
+	return a.Status == "published" && a.PublishedAt != nil && !a.PublishedAt.After(now) && !a.DeletedAt.Valid
+}
+
+func mapSeriesDto(s models.Series, articleCount int) dto.SeriesDto {
+	return dto.SeriesDto{
+		ID:           s.ID,
+		Title:        s.Title,
+		Slug:         s.Slug,
+		Description:  s.Description,
+		CoverImage:   s.CoverImage,
+		ArticleCount: articleCount,
+		CreatedAt:    s.CreatedAt,
+		UpdatedAt:    s.UpdatedAt,
+	}
+}
+
+// mapSeriesArticleDto position 以傳入值為準（前台略過未發佈文章後重新連續編號）。
+func mapSeriesArticleDto(m models.SeriesArticle, position int) dto.SeriesArticleDto {
+	status := m.Article.Status
+	if m.Article.DeletedAt.Valid {
+		status = seriesMemberTrashed
+	}
+	return dto.SeriesArticleDto{
+		ID:          m.Article.ID,
+		Title:       m.Article.Title,
+		Slug:        m.Article.Slug,
+		Summary:     m.Article.Summary,
+		PublishedAt: fmtTimePtr(m.Article.PublishedAt),
+		Position:    position,
+		Note:        m.Note,
+		Status:      status,
+	}
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/paulhuang/paulfun-blogger/internal/apierror"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"github.com/paulhuang/paulfun-blogger/internal/sitemap"
+	"gorm.io/gorm"
+)
+
+// sitemapCacheTTL 快取上限。文章異動會透過 ArticleService.OnPublicChange 立即清快取；
+// TTL 用來兜住分類 / 標籤改名、刪除等不經過 ArticleService 的變動。
+const sitemapCacheTTL = time.Hour
+
+// SitemapDoc 產生好的 sitemap / robots.txt 內容。
+// LastModified 供 HTTP 標頭使用，為快取建置時間（只會往前；文章移出 sitemap 時不會倒退）。
+type SitemapDoc struct {
+	Body         []byte
+	LastModified time.Time
+}
+
+// sitemapSnapshot 一次建置的結果：index（或單一 urlset）與各分頁。
+type sitemapSnapshot struct {
+	builtAt time.Time
+	root    *SitemapDoc   // /sitemap.xml
+	pages   []*SitemapDoc // /sitemaps/{n}.xml（網址數未超過上限時為空）
+}
+
+// SitemapService 產生 /sitemap.xml 與 /robots.txt，結果快取在記憶體。
+//
+// 列出已發佈（published_at 已到）的文章、有已發佈文章的分類與標籤；
+// 網址數超過 sitemap.MaxURLs 時 /sitemap.xml 改為 sitemap index，分頁在 /sitemaps/{n}.xml。
+type SitemapService struct {
+	db      *gorm.DB
+	baseURL string
+	robots  *SitemapDoc
+
+	mu    sync.Mutex
+	cache *sitemapSnapshot
+}
+
+func NewSitemapService(db *gorm.DB, baseURL string) *SitemapService {
+	s := &SitemapService{db: db, baseURL: strings.TrimRight(baseURL, "/")}
+	s.robots = &SitemapDoc{Body: []byte(s.robotsTxt()), LastModified: time.Now().UTC()}
+	return s
+}
+
+// Invalidate 清除快取，下一次請求重新產生。
+func (s *SitemapService) Invalidate() {
+	s.mu.Lock()
+	s.cache = nil
+	s.mu.Unlock()
+}
+
+// Root 取得 /sitemap.xml（urlset 或 sitemap index）。
+func (s *SitemapService) Root() (*SitemapDoc, error) {
+	snap, err := s.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return snap.root, nil
+}
+
+// Page 取得 sitemap index 的第 n 頁（1-based）。
+func (s *SitemapService) Page(n int) (*SitemapDoc, error) {
+	snap, err := s.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	if n < 1 || n > len(snap.pages) {
+		return nil, fmt.Errorf("%w: sitemap 第 %d 頁不存在", apierror.ErrNotFound, n)
+	}
+	return snap.pages[n-1], nil
+}
+
+// Robots 取得 /robots.txt（內容只依賴 BaseURL，啟動時產生一次）。
+func (s *SitemapService) Robots() *SitemapDoc {
+	return s.robots
+}
+
+func (s *SitemapService) robotsTxt() string {
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+	b.WriteString("Allow: /\n")
+	for _, p := range []string{"/admin", "/login", "/register", "/api/"} {
+		b.WriteString("Disallow: " + p + "\n")
+	}
+	b.WriteString("\nSitemap: " + s.baseURL + "/sitemap.xml\n")
+	return b.String()
+}
+
+func (s *SitemapService) snapshot() (*sitemapSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cache != nil && time.Since(s.cache.builtAt) < sitemapCacheTTL {
+		return s.cache, nil
+	}
+	snap, err := s.build()
+	if err != nil {
+		return nil, err
+	}
+	s.cache = snap
+	return snap, nil
+}
+
+// build 查詢所有網址並依 sitemap.MaxURLs 切分。
+func (s *SitemapService) build() (*sitemapSnapshot, error) {
+	urls, err := s.collectURLs()
+	if err != nil {
+		return nil, err
+	}
+	snap := &sitemapSnapshot{builtAt: time.Now()}
+
+	if len(urls) <= sitemap.MaxURLs {
+		body, err := sitemap.URLSet(urls)
+		if err != nil {
+			return nil, err
+		}
+		snap.root = &SitemapDoc{Body: body, LastModified: snap.builtAt}
+		return snap, nil
+	}
+
+	var index []sitemap.URL
+	for start := 0; start < len(urls); start += sitemap.MaxURLs {
+		end := start + sitemap.MaxURLs
+		if end > len(urls) {
+			end = len(urls)
+		}
+		chunk := urls[start:end]
+		body, err := sitemap.URLSet(chunk)
+		if err != nil {
+			return nil, err
+		}
+		snap.pages = append(snap.pages, &SitemapDoc{Body: body, LastModified: snap.builtAt})
+		index = append(index, sitemap.URL{
+			Loc:     fmt.Sprintf("%s/sitemaps/%d.xml", s.baseURL, len(snap.pages)),
+			LastMod: latestLastMod(chunk),
+		})
+	}
+	body, err := sitemap.Index(index)
+	if err != nil {
+		return nil, err
+	}
+	snap.root = &SitemapDoc{Body: body, LastModified: snap.builtAt}
+	return snap, nil
+}
+
+// collectURLs 首頁、分類總覽、文章、分類、標籤。
+// 文章 lastmod 取 UpdatedAt 與 PublishedAt 較新者；分類 / 標籤取其下文章的最新 lastmod。
+func (s *SitemapService) collectURLs() ([]sitemap.URL, error) {
+	now := time.Now().UTC()
+
+	var articles []models.Article
+	if err := s.db.Select("id", "published_at", "updated_at").
+		Where("status = ? AND published_at <= ?", "published", now).
+		Order("published_at DESC, id DESC").
+		Find(&articles).Error; err != nil {
+		return nil, err
+	}
+
+	type slugLastMod struct {
+		Slug    string
+		LastMod time.Time
+	}
+	const lastModExpr = "MAX(GREATEST(a.published_at, COALESCE(a.updated_at, a.published_at))) AS last_mod"
+	const visible = "a.status = 'published' AND a.published_at <= ? AND a.deleted_at IS NULL"
+
+	var cats []slugLastMod
+	if err := s.db.Raw(`
+		SELECT c.slug, `+lastModExpr+`
+		FROM categories c
+		JOIN articles a ON a.category_id = c.id
+		WHERE `+visible+`
+		GROUP BY c.slug
+		ORDER BY c.slug`, now).Scan(&cats).Error; err != nil {
+		return nil, err
+	}
+
+	var tags []slugLastMod
+	if err := s.db.Raw(`
+		SELECT t.slug, `+lastModExpr+`
+		FROM tags t
+		JOIN article_tags at ON at.tag_id = t.id
+		JOIN articles a ON a.id = at.article_id
+		WHERE `+visible+`
+		GROUP BY t.slug
+		ORDER BY t.slug`, now).Scan(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	urls := make([]sitemap.URL, 0, len(articles)+len(cats)+len(tags)+2)
+	var siteLastMod time.Time
+	articleURLs := make([]sitemap.URL, len(articles))
+	for i, a := range articles {
+		lastMod := *a.PublishedAt
+		if a.UpdatedAt != nil && a.UpdatedAt.After(lastMod) {
+			lastMod = *a.UpdatedAt
+		}
+		if lastMod.After(siteLastMod) {
+			siteLastMod = lastMod
+		}
+		articleURLs[i] = sitemap.URL{Loc: fmt.Sprintf("%s/articles/%d", s.baseURL, a.ID), LastMod: lastMod}
+	}
+
+	urls = append(urls,
+		sitemap.URL{Loc: s.baseURL + "/", LastMod: siteLastMod},
+		sitemap.URL{Loc: s.baseURL + "/categories", LastMod: siteLastMod},
+	)
+	urls = append(urls, articleURLs...)
+	for _, c := range cats {
+		urls = append(urls, sitemap.URL{Loc: s.baseURL + "/categories/" + url.PathEscape(c.Slug), LastMod: c.LastMod})
+	}
+	for _, t := range tags {
+		urls = append(urls, sitemap.URL{Loc: s.baseURL + "/tags/" + url.PathEscape(t.Slug), LastMod: t.LastMod})
+	}
+	return urls, nil
+}
+
+func latestLastMod(urls []sitemap.URL) time.Time {
+	var latest time.Time
+	for _, u := range urls {
+		if u.LastMod.After(latest) {
+			latest = u.LastMod
+		}
+	}
+	return latest
+}
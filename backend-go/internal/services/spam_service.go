@@ -0,0 +1,197 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/paulhuang/paulfun-blogger/internal/apierror"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"github.com/paulhuang/paulfun-blogger/internal/spam"
+	"gorm.io/gorm"
+)
+
+// SpamSettings 垃圾訊息檢查設定（來自 Config）。
+//
+// Strategies 為啟用的策略名稱，依序執行：honeypot | token | links | blocklist | duplicate。
+type SpamSettings struct {
+	Enabled          bool
+	Strategies       []string
+	MinSubmitSeconds int
+	MaxLinks         int
+	Blocklist        []string
+	DuplicateWindow  time.Duration
+	LogAllowed       bool   // true 時放行的請求也寫入判定紀錄
+	TokenSecret      string // 表單 token 簽章金鑰
+}
+
+// SpamService 供公開寫入端點（留言、按讚）詢問是否為垃圾訊息，並記錄判定結果。
+type SpamService struct {
+	db       *gorm.DB
+	settings SpamSettings
+	tokens   *spam.Tokens
+	filter   *spam.Filter
+}
+
+func NewSpamService(db *gorm.DB, settings SpamSettings) *SpamService {
+	tokens := spam.NewTokens(settings.TokenSecret)
+
+	var strategies []spam.Strategy
+	for _, name := range settings.Strategies {
+		switch strings.TrimSpace(name) {
+		case spam.StrategyHoneypot:
+			strategies = append(strategies, spam.Honeypot{})
+		case spam.StrategyToken:
+			strategies = append(strategies, spam.MinSubmitTime{
+				Tokens: tokens,
+				Min:    time.Duration(settings.MinSubmitSeconds) * time.Second,
+			})
+		case spam.StrategyLinks:
+			strategies = append(strategies, spam.LinkLimit{Max: settings.MaxLinks})
+		case spam.StrategyBlocklist:
+			strategies = append(strategies, spam.NewBlocklist(settings.Blocklist))
+		case spam.StrategyDuplicate:
+			strategies = append(strategies, spam.NewDuplicate(settings.DuplicateWindow))
+		case "":
+		default:
+			log.Printf("[spam] 未知的策略 %q，已略過", name)
+		}
+	}
+
+	return &SpamService{
+		db:       db,
+		settings: settings,
+		tokens:   tokens,
+		filter:   spam.NewFilter(strategies...),
+	}
+}
+
+// Check 判定一次提交是否為 spam，並依設定寫入判定紀錄（寫入失敗只記 log，不影響判定）。
+// 停用時一律放行。
+func (s *SpamService) Check(sub spam.Submission) spam.Decision {
+	if !s.settings.Enabled {
+		return spam.Decision{}
+	}
+
+	d := s.filter.Check(&sub)
+	if !d.Spam && !s.settings.LogAllowed {
+		return d
+	}
+
+	verdict := models.SpamVerdictAllow
+	if d.Spam {
+		verdict = models.SpamVerdictSpam
+	}
+	rec := models.SpamDecision{
+		Kind:       sub.Kind,
+		ArticleID:  sub.ArticleID,
+		Verdict:    verdict,
+		Strategy:   d.Strategy,
+		Reason:     truncateRunes(d.Reason, 199),
+		IPAddress:  sub.IP,
+		UserAgent:  truncateRunes(sub.UserAgent, 299),
+		AuthorName: truncateRunes(sub.AuthorName, 49),
+		Excerpt:    truncateRunes(strings.Join(strings.Fields(sub.Content), " "), 199),
+	}
+	if err := s.db.Create(&rec).Error; err != nil {
+		log.Printf("[spam] 寫入判定紀錄失敗 kind=%s article_id=%d: %v", sub.Kind, sub.ArticleID, err)
+	}
+	return d
+}
+
+// Record 提交成功寫入後呼叫，讓重複提交檢查記下這次內容。停用時不記錄。
+func (s *SpamService) Record(sub spam.Submission) {
+	if !s.settings.Enabled {
+		return
+	}
+	s.filter.Record(&sub)
+}
+
+// IssueToken 簽發表單 token。
+func (s *SpamService) IssueToken() dto.FormTokenDto {
+	now := time.Now()
+	return dto.FormTokenDto{
+		Token:            s.tokens.Issue(now),
+		MinSubmitSeconds: s.settings.MinSubmitSeconds,
+		ExpiresAt:        now.Add(spam.TokenMaxAge).UTC(),
+	}
+}
+
+// Settings 目前生效的設定。
+func (s *SpamService) Settings() dto.SpamSettingsDto {
+	return dto.SpamSettingsDto{
+		Enabled:                s.settings.Enabled,
+		Strategies:             s.filter.Strategies(),
+		MinSubmitSeconds:       s.settings.MinSubmitSeconds,
+		MaxLinks:               s.settings.MaxLinks,
+		BlocklistSize:          spam.NewBlocklist(s.settings.Blocklist).Len(),
+		DuplicateWindowMinutes: int(s.settings.DuplicateWindow / time.Minute),
+		LogAllowed:             s.settings.LogAllowed,
+	}
+}
+
+// ListDecisions 查詢判定紀錄（新 → 舊）。
+func (s *SpamService) ListDecisions(q dto.SpamDecisionQueryParams) (dto.PagedResponse[dto.SpamDecisionDto], error) {
+	query := s.db.Model(&models.SpamDecision{})
+	switch q.Verdict {
+	case "":
+	case models.SpamVerdictSpam, models.SpamVerdictAllow:
+		query = query.Where("verdict = ?", q.Verdict)
+	default:
+		return dto.PagedResponse[dto.SpamDecisionDto]{}, fmt.Errorf("%w: verdict 僅接受 spam / allow", apierror.ErrBadRequest)
+	}
+	if q.Kind != "" {
+		query = query.Where("kind = ?", q.Kind)
+	}
+	if q.ArticleID != 0 {
+		query = query.Where("article_id = ?", q.ArticleID)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return dto.PagedResponse[dto.SpamDecisionDto]{}, err
+	}
+
+	page := q.GetPage()
+	pageSize := q.GetPageSize()
+
+	var records []models.SpamDecision
+	if err := query.Order("created_at DESC, id DESC").
+		Offset((page - 1) * pageSize).Limit(pageSize).
+		Find(&records).Error; err != nil {
+		return dto.PagedResponse[dto.SpamDecisionDto]{}, err
+	}
+
+	items := make([]dto.SpamDecisionDto, len(records))
+	for i, r := range records {
+		items[i] = dto.SpamDecisionDto{
+			ID:         r.ID,
+			Kind:       r.Kind,
+			ArticleID:  r.ArticleID,
+			Verdict:    r.Verdict,
+			Strategy:   r.Strategy,
+			Reason:     r.Reason,
+			IPAddress:  r.IPAddress,
+			UserAgent:  r.UserAgent,
+			AuthorName: r.AuthorName,
+			Excerpt:    r.Excerpt,
+			CreatedAt:  r.CreatedAt,
+		}
+	}
+
+	totalPages := (int(totalCount) + pageSize - 1) / pageSize
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	return dto.PagedResponse[dto.SpamDecisionDto]{
+		Items:           items,
+		TotalCount:      int(totalCount),
+		Page:            page,
+		PageSize:        pageSize,
+		TotalPages:      totalPages,
+		HasPreviousPage: page > 1,
+		HasNextPage:     page < totalPages,
+	}, nil
+}
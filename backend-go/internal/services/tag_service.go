@@ -0,0 +1,208 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/paulhuang/paulfun-blogger/internal/apierror"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"gorm.io/gorm"
+)
+
+// TagService 後台標籤管理（建立 / 改名 / 刪除 / 合併）。
+type TagService struct {
+	db *gorm.DB
+}
+
+func NewTagService(db *gorm.DB) *TagService {
+	return &TagService{db: db}
+}
+
+// List 取得所有標籤，含文章數（不分狀態，不含垃圾桶）。
+func (s *TagService) List() ([]dto.TagDto, error) {
+	var rows []dto.TagDto
+	if err := s.db.Raw(`
+		SELECT t.id, t.name, t.slug,
+		       COUNT(a.id) AS article_count
+		FROM tags t
+		LEFT JOIN article_tags at ON at.tag_id = t.id
+		LEFT JOIN articles a
+		       ON a.id = at.article_id
+		          AND a.deleted_at IS NULL
+		GROUP BY t.id, t.name, t.slug
+		ORDER BY t.name ASC
+	`).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// Create 建立新標籤。
+func (s *TagService) Create(req dto.CreateTagRequest) (*dto.TagDto, error) {
+	slug := req.Slug
+	if slug == "" {
+		slug = generateSlug(req.Name)
+	}
+	if exists, err := s.slugExists(slug, 0); err != nil {
+		return nil, err
+	} else if exists {
+		return nil, fmt.Errorf("%w: slug %q 已被使用", apierror.ErrConflict, slug)
+	}
+
+	tag := models.Tag{Name: req.Name, Slug: slug}
+	if err := s.db.Create(&tag).Error; err != nil {
+		return nil, fmt.Errorf("建立標籤失敗: %w", err)
+	}
+	return &dto.TagDto{ID: tag.ID, Name: tag.Name, Slug: tag.Slug}, nil
+}
+
+// Update 改名 / 改 slug（全欄位替換語義）。
+func (s *TagService) Update(id uint, req dto.UpdateTagRequest) (*dto.TagDto, error) {
+	tag, err := s.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Slug != tag.Slug {
+		if exists, err := s.slugExists(req.Slug, id); err != nil {
+			return nil, err
+		} else if exists {
+			return nil, fmt.Errorf("%w: slug %q 已被使用", apierror.ErrConflict, req.Slug)
+		}
+	}
+
+	tag.Name = req.Name
+	tag.Slug = req.Slug
+	if err := s.db.Save(tag).Error; err != nil {
+		return nil, fmt.Errorf("更新標籤失敗: %w", err)
+	}
+	return s.get(id)
+}
+
+// Delete 刪除標籤；先清 article_tags pivot（m2m 預設 FK 是 RESTRICT）。
+func (s *TagService) Delete(id uint) error {
+	tag, err := s.find(id)
+	if err != nil {
+		return err
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM article_tags WHERE tag_id = ?", tag.ID).Error; err != nil {
+			return fmt.Errorf("清除文章關聯失敗: %w", err)
+		}
+		if err := tx.Delete(tag).Error; err != nil {
+			return fmt.Errorf("刪除標籤失敗: %w", err)
+		}
+		return nil
+	})
+}
+
+// Merge 把 source 標籤的所有文章關聯移到 target，並刪除 source 標籤（同一個 transaction）。
+// 已同時掛 source 與 target 的文章不會重複關聯。
+func (s *TagService) Merge(req dto.MergeTagsRequest) (*dto.MergeTagsResultDto, error) {
+	sourceIDs := uniqueUints(req.SourceIDs)
+	for _, id := range sourceIDs {
+		if id == req.TargetID {
+			return nil, fmt.Errorf("%w: targetId 不可同時出現在 sourceIds", apierror.ErrBadRequest)
+		}
+	}
+	if _, err := s.find(req.TargetID); err != nil {
+		return nil, err
+	}
+	var count int64
+	if err := s.db.Model(&models.Tag{}).Where("id IN ?", sourceIDs).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if int(count) != len(sourceIDs) {
+		return nil, fmt.Errorf("%w: 部分 sourceIds 不存在", apierror.ErrNotFound)
+	}
+
+	var moved int64
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Exec(`
+			INSERT INTO article_tags (article_id, tag_id)
+			SELECT DISTINCT article_id, ? FROM article_tags WHERE tag_id IN ?
+			ON CONFLICT DO NOTHING
+		`, req.TargetID, sourceIDs)
+		if res.Error != nil {
+			return fmt.Errorf("搬移文章關聯失敗: %w", res.Error)
+		}
+		moved = res.RowsAffected
+		if err := tx.Exec("DELETE FROM article_tags WHERE tag_id IN ?", sourceIDs).Error; err != nil {
+			return fmt.Errorf("清除來源標籤關聯失敗: %w", err)
+		}
+		if err := tx.Where("id IN ?", sourceIDs).Delete(&models.Tag{}).Error; err != nil {
+			return fmt.Errorf("刪除來源標籤失敗: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.get(req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.MergeTagsResultDto{
+		Target:        *target,
+		MergedTagIDs:  sourceIDs,
+		ArticlesMoved: int(moved),
+	}, nil
+}
+
+// ── helpers ─────────────────────────────────────────────
+
+func (s *TagService) find(id uint) (*models.Tag, error) {
+	var tag models.Tag
+	if err := s.db.First(&tag, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apierror.ErrNotFound
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// get 取得單一標籤（含文章數）。
+func (s *TagService) get(id uint) (*dto.TagDto, error) {
+	var row dto.TagDto
+	if err := s.db.Raw(`
+		SELECT t.id, t.name, t.slug,
+		       COUNT(a.id) AS article_count
+		FROM tags t
+		LEFT JOIN article_tags at ON at.tag_id = t.id
+		LEFT JOIN articles a
+		       ON a.id = at.article_id
+		          AND a.deleted_at IS NULL
+		WHERE t.id = ?
+		GROUP BY t.id, t.name, t.slug
+	`, id).Scan(&row).Error; err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+func (s *TagService) slugExists(slug string, excludeID uint) (bool, error) {
+	var count int64
+	q := s.db.Model(&models.Tag{}).Where("slug = ?", slug)
+	if excludeID > 0 {
+		q = q.Where("id <> ?", excludeID)
+	}
+	if err := q.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func uniqueUints(ids []uint) []uint {
+	seen := map[uint]bool{}
+	out := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/paulhuang/paulfun-blogger/internal/textseg"
+)
+
+// ── 文字差異比對（版本 diff 用）──────────────────────────────────────────
+
+// diffMaxCells LCS 表格上限；超過時中段直接視為整段刪除 + 整段新增，避免吃爆記憶體。
+const diffMaxCells = 4_000_000
+
+const (
+	diffEqual  = "equal"
+	diffInsert = "insert"
+	diffDelete = "delete"
+)
+
+type diffOp struct {
+	Op   string
+	Text string
+}
+
+// diffTokens 以 LCS 比對兩個 token 序列，回傳 equal / insert / delete 操作序列。
+// 先剝除共同前後綴（編輯多半是局部修改），中段才做 DP。
+func diffTokens(a, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, t := range a[:prefix] {
+		ops = append(ops, diffOp{diffEqual, t})
+	}
+	ops = append(ops, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, t := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{diffEqual, t})
+	}
+	return ops
+}
+
+func diffMiddle(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 || n*m > diffMaxCells {
+		ops := make([]diffOp, 0, n+m)
+		for _, t := range a {
+			ops = append(ops, diffOp{diffDelete, t})
+		}
+		for _, t := range b {
+			ops = append(ops, diffOp{diffInsert, t})
+		}
+		return ops
+	}
+
+	// lcs[i][j] = a[i:] 與 b[j:] 的 LCS 長度
+	lcs := make([][]int32, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{diffEqual, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{diffDelete, a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{diffInsert, b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{diffDelete, a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{diffInsert, b[j]})
+	}
+	return ops
+}
+
+// mergeDiffOps 合併相鄰同類操作（逐詞 diff 輸出用，減少碎片）。
+func mergeDiffOps(ops []diffOp) []diffOp {
+	var out []diffOp
+	for _, op := range ops {
+		if len(out) > 0 && out[len(out)-1].Op == op.Op {
+			out[len(out)-1].Text += op.Text
+			continue
+		}
+		out = append(out, op)
+	}
+	return out
+}
+
+// ── HTML → 純文字行 ──────────────────────────────────────────────────────
+
+// blockBoundaryRe 區塊層級標籤視為換行，讓段落 / 標題 / 清單項各自成為一行。
+var blockBoundaryRe = regexp.MustCompile(`(?i)<\s*(br\s*/?|/\s*(p|div|h[1-6]|li|blockquote|pre|tr|table|ul|ol|figure|figcaption))\s*>`)
+
+// htmlToLines 去除 HTML 標籤後切成非空白行。
+func htmlToLines(content *string) []string {
+	if content == nil {
+		return []string{}
+	}
+	text := blockBoundaryRe.ReplaceAllString(*content, "\n")
+	text = htmlTagRe.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// splitWords 把一行切成逐詞 diff 的 token：英數字連續字元為一詞，
+// 中日韓文字逐字，空白與標點各自成 token。串接所有 token 可還原原字串。
+func splitWords(s string) []string {
+	var tokens []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range s {
+		if (unicode.IsLetter(r) || unicode.IsDigit(r)) && !textseg.IsCJK(r) {
+			word.WriteRune(r)
+			continue
+		}
+		flush()
+		tokens = append(tokens, string(r))
+	}
+	flush()
+	return tokens
+}
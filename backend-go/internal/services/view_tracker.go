@@ -0,0 +1,263 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/paulhuang/paulfun-blogger/internal/apierror"
+	"github.com/paulhuang/paulfun-blogger/internal/dto"
+	"github.com/paulhuang/paulfun-blogger/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// botUserAgent 已知爬蟲、預覽抓取與命令列工具。
+// "node" / undici 為前台 SSR（generateMetadata）抓文章時的 UA，不是讀者瀏覽。
+var botUserAgent = regexp.MustCompile(`(?i)bot|crawl|spider|slurp|archiver|facebookexternalhit|embedly|preview|` +
+	`curl|wget|httpie|python-requests|python-urllib|aiohttp|go-http-client|okhttp|java/|libwww|` +
+	`scrapy|headless|phantomjs|lighthouse|pingdom|uptime|monitor|^node$|undici|axios/|node-fetch`)
+
+// IsBotUserAgent 空 UA 也視為非讀者。
+func IsBotUserAgent(ua string) bool {
+	return ua == "" || botUserAgent.MatchString(ua)
+}
+
+// ViewTrackerSettings 瀏覽事件管線設定（來自 Config）。
+type ViewTrackerSettings struct {
+	DedupeWindow  time.Duration // 同一讀者同篇文章在此時間內只算一次
+	FlushInterval time.Duration // 批次寫入間隔
+	BufferSize    int           // 事件佇列容量
+}
+
+// ViewEvent 一次文章瀏覽。Visitors 為讀者去重鍵（fingerprint.Reader.Keys）：
+// 任一鍵在時間窗內計入過即不重複計算，計入時所有鍵一併記下。
+type ViewEvent struct {
+	ArticleID uint
+	Visitors  []string
+	UserAgent string
+}
+
+// viewBucket 批次累加的單位：一篇文章的某一天（UTC）。
+type viewBucket struct {
+	articleID uint
+	day       string // 2006-01-02
+}
+
+// ViewTracker 文章瀏覽數管線：過濾爬蟲 → 依讀者去重 → 佇列 → 定期批次寫入。
+//
+// Record 不會阻塞請求：佇列滿了直接丟棄。每次寫入在同一個交易內累加
+// articles.view_count 與 article_stats 當日數字。去重紀錄只在記憶體，
+// 重啟後歸零；多個 instance 之間也不共享（同一讀者最多各算一次）。
+type ViewTracker struct {
+	db       *gorm.DB
+	settings ViewTrackerSettings
+	events   chan viewBucket
+	done     chan struct{}
+
+	mu     sync.Mutex
+	seen   map[string]time.Time // articleID|visitor → 最後計入時間
+	closed bool
+}
+
+func NewViewTracker(db *gorm.DB, settings ViewTrackerSettings) *ViewTracker {
+	if settings.FlushInterval <= 0 {
+		settings.FlushInterval = 10 * time.Second
+	}
+	if settings.BufferSize <= 0 {
+		settings.BufferSize = 4096
+	}
+	return &ViewTracker{
+		db:       db,
+		settings: settings,
+		events:   make(chan viewBucket, settings.BufferSize),
+		done:     make(chan struct{}),
+		seen:     make(map[string]time.Time),
+	}
+}
+
+// Start 啟動背景寫入 goroutine；只能呼叫一次。
+func (t *ViewTracker) Start() {
+	go t.loop()
+}
+
+// Close 停止接收事件，寫完佇列中剩餘的瀏覽數後返回。
+func (t *ViewTracker) Close() {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return
+	}
+	t.closed = true
+	close(t.events)
+	t.mu.Unlock()
+	<-t.done
+}
+
+// Record 登記一次瀏覽，回傳是否計入（爬蟲、時間窗內重複、佇列已滿或已關閉皆不計）。
+func (t *ViewTracker) Record(ev ViewEvent) bool {
+	if ev.ArticleID == 0 || IsBotUserAgent(ev.UserAgent) {
+		return false
+	}
+	now := time.Now()
+	keys := make([]string, len(ev.Visitors))
+	for i, v := range ev.Visitors {
+		keys[i] = fmt.Sprintf("%d|%s", ev.ArticleID, v)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return false
+	}
+	for _, key := range keys {
+		if last, ok := t.seen[key]; ok && now.Sub(last) < t.settings.DedupeWindow {
+			return false
+		}
+	}
+
+	select {
+	case t.events <- viewBucket{articleID: ev.ArticleID, day: now.UTC().Format("2006-01-02")}:
+		for _, key := range keys {
+			t.seen[key] = now
+		}
+		return true
+	default:
+		log.Printf("[views] 佇列已滿，丟棄 article_id=%d 的瀏覽", ev.ArticleID)
+		return false
+	}
+}
+
+func (t *ViewTracker) loop() {
+	defer close(t.done)
+	ticker := time.NewTicker(t.settings.FlushInterval)
+	defer ticker.Stop()
+
+	pending := make(map[viewBucket]int)
+	for {
+		select {
+		case b, ok := <-t.events:
+			if !ok {
+				t.flush(pending)
+				return
+			}
+			pending[b]++
+		case <-ticker.C:
+			t.flush(pending)
+			t.sweep()
+		}
+	}
+}
+
+// flush 寫入累積的瀏覽數；失敗時保留在 pending 等下一輪重試。
+func (t *ViewTracker) flush(pending map[viewBucket]int) {
+	if len(pending) == 0 {
+		return
+	}
+
+	perArticle := make(map[uint]int)
+	stats := make([]models.ArticleStat, 0, len(pending))
+	now := time.Now()
+	for b, n := range pending {
+		day, _ := time.Parse("2006-01-02", b.day)
+		perArticle[b.articleID] += n
+		stats = append(stats, models.ArticleStat{ArticleID: b.articleID, Day: day, Views: n, UpdatedAt: now})
+	}
+	ids := make([]uint, 0, len(perArticle))
+	for id := range perArticle {
+		ids = append(ids, id)
+	}
+	// 固定順序更新，避免多個 instance 同時 flush 時互相 deadlock
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	// 文章可能在事件排隊期間被永久刪除，只寫入仍存在的文章（含垃圾桶中的）
+	var existing []uint
+	err := t.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Article{}).Where("id IN ?", ids).
+			Order("id").Pluck("id", &existing).Error; err != nil {
+			return err
+		}
+		alive := make(map[uint]bool, len(existing))
+		for _, id := range existing {
+			alive[id] = true
+			if err := tx.Unscoped().Model(&models.Article{}).Where("id = ?", id).
+				UpdateColumn("view_count", gorm.Expr("view_count + ?", perArticle[id])).Error; err != nil {
+				return err
+			}
+		}
+
+		rows := stats[:0]
+		for _, st := range stats {
+			if alive[st.ArticleID] {
+				rows = append(rows, st)
+			}
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "article_id"}, {Name: "day"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"views":      gorm.Expr("article_stats.views + EXCLUDED.views"),
+				"updated_at": gorm.Expr("EXCLUDED.updated_at"),
+			}),
+		}).Create(&rows).Error
+	})
+	if err != nil {
+		log.Printf("[views] 寫入瀏覽數失敗（%d 篇文章，下一輪重試）: %v", len(ids), err)
+		return
+	}
+	for b := range pending {
+		delete(pending, b)
+	}
+}
+
+// sweep 清掉超過去重時間窗的紀錄，避免記憶體無限成長。
+func (t *ViewTracker) sweep() {
+	cutoff := time.Now().Add(-t.settings.DedupeWindow)
+	t.mu.Lock()
+	for k, last := range t.seen {
+		if last.Before(cutoff) {
+			delete(t.seen, k)
+		}
+	}
+	t.mu.Unlock()
+}
+
+// GetViewStats 文章最近 N 天（含今天，UTC）的每日瀏覽數，無資料的日子補 0。
+func (s *ArticleService) GetViewStats(id uint, q dto.ViewStatsQueryParams) (*dto.ArticleViewStatsDto, error) {
+	var article models.Article
+	if err := s.db.Unscoped().Select("id", "title", "view_count").First(&article, id).Error; err != nil {
+		return nil, fmt.Errorf("%w: 文章不存在", apierror.ErrNotFound)
+	}
+
+	days := q.GetDays()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	from := today.AddDate(0, 0, -(days - 1))
+
+	var rows []models.ArticleStat
+	if err := s.db.Where("article_id = ? AND day >= ?", id, from.Format("2006-01-02")).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	byDay := make(map[string]int, len(rows))
+	for _, r := range rows {
+		byDay[r.Day.UTC().Format("2006-01-02")] += r.Views
+	}
+
+	result := &dto.ArticleViewStatsDto{
+		ArticleID: article.ID,
+		Title:     article.Title,
+		ViewCount: article.ViewCount,
+		Days:      make([]dto.DailyViewsDto, days),
+	}
+	for i := 0; i < days; i++ {
+		date := from.AddDate(0, 0, i).Format("2006-01-02")
+		result.Days[i] = dto.DailyViewsDto{Date: date, Views: byDay[date]}
+		result.RangeViews += byDay[date]
+	}
+	return result, nil
+}
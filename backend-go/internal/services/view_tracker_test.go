@@ -0,0 +1,33 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/paulhuang/paulfun-blogger/internal/fingerprint"
+)
+
+// 新讀者第一次瀏覽時拿到 cookie，帶著 cookie 重新整理不應再計入。
+func TestViewTrackerFirstRefreshNotDoubleCounted(t *testing.T) {
+	tracker := NewViewTracker(nil, ViewTrackerSettings{DedupeWindow: 30 * time.Minute, BufferSize: 16})
+	readers := fingerprint.New("secret", time.Hour)
+	const ip, ua = "203.0.113.7", "Mozilla/5.0"
+
+	first := readers.Identify("", ip, ua)
+	if !tracker.Record(ViewEvent{ArticleID: 1, Visitors: first.Keys(), UserAgent: ua}) {
+		t.Fatal("first view not counted")
+	}
+
+	refresh := readers.Identify(first.NewCookie, ip, ua)
+	if refresh.Anonymous() {
+		t.Fatal("cookie from first response not accepted")
+	}
+	if tracker.Record(ViewEvent{ArticleID: 1, Visitors: refresh.Keys(), UserAgent: ua}) {
+		t.Error("refresh with new cookie counted again")
+	}
+
+	// 同一讀者的另一篇文章照常計入
+	if !tracker.Record(ViewEvent{ArticleID: 2, Visitors: refresh.Keys(), UserAgent: ua}) {
+		t.Error("view of another article not counted")
+	}
+}
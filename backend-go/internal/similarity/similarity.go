@@ -0,0 +1,175 @@
+// Package similarity 以 TF-IDF 加權的餘弦相似度找出內容相近的文章。
+//
+// 文件由數個加權欄位組成（例如標題權重高於內文），每個欄位以 textseg 斷詞；
+// 另可加入標籤、分類等「詞」（Terms），讓共用少見標籤的文章得到較高分數，
+// 而幾乎每篇都有的標籤會因 IDF 趨近 0 而不影響結果。
+//
+// 索引全部在記憶體：向量 L2 正規化後以倒排表計算內積，適合數千篇以內的部落格規模。
+package similarity
+
+import (
+	"math"
+	"sort"
+
+	"github.com/paulhuang/paulfun-blogger/internal/textseg"
+)
+
+// Field 文件中的一段文字及其權重。
+type Field struct {
+	Text   string
+	Weight float64
+}
+
+// Document 一篇待索引的文章。Terms 為不經斷詞、直接計入的詞（如 "tag:golang"），權重同 TermWeight。
+type Document struct {
+	ID         uint
+	Fields     []Field
+	Terms      []string
+	TermWeight float64
+}
+
+// Match 一筆相似結果，Score 介於 0 到 1。
+type Match struct {
+	ID    uint
+	Score float64
+}
+
+type posting struct {
+	id     uint
+	weight float64
+}
+
+// Index 已建好的相似度索引，建好後唯讀，可同時查詢。
+type Index struct {
+	idf      map[string]float64
+	vectors  map[uint]map[string]float64
+	postings map[string][]posting
+}
+
+// Build 建立索引。
+func Build(docs []Document) *Index {
+	ix := &Index{
+		idf:      make(map[string]float64),
+		vectors:  make(map[uint]map[string]float64, len(docs)),
+		postings: make(map[string][]posting),
+	}
+
+	counts := make(map[uint]map[string]float64, len(docs))
+	df := make(map[string]int)
+	for _, d := range docs {
+		tf := termFrequencies(d)
+		counts[d.ID] = tf
+		for term := range tf {
+			df[term]++
+		}
+	}
+
+	// 平滑 IDF：出現在所有文件的詞 idf = log((N+1)/(N+1)) = 0
+	n := float64(len(docs))
+	for term, c := range df {
+		ix.idf[term] = math.Log((n + 1) / (float64(c) + 1))
+	}
+
+	for id, tf := range counts {
+		vec := ix.weigh(tf)
+		ix.vectors[id] = vec
+		for term, w := range vec {
+			ix.postings[term] = append(ix.postings[term], posting{id: id, weight: w})
+		}
+	}
+	return ix
+}
+
+// Len 索引中的文件數。
+func (ix *Index) Len() int {
+	return len(ix.vectors)
+}
+
+// Contains 文件是否在索引中。
+func (ix *Index) Contains(id uint) bool {
+	_, ok := ix.vectors[id]
+	return ok
+}
+
+// Similar 與索引中文件 id 最相似的前 k 篇（不含自己）。id 不在索引中時回 nil。
+func (ix *Index) Similar(id uint, k int, minScore float64, exclude func(uint) bool) []Match {
+	vec, ok := ix.vectors[id]
+	if !ok {
+		return nil
+	}
+	return ix.search(vec, id, k, minScore, exclude)
+}
+
+// SimilarTo 與不在索引中的文件（例如草稿）最相似的前 k 篇；IDF 沿用索引。
+func (ix *Index) SimilarTo(doc Document, k int, minScore float64, exclude func(uint) bool) []Match {
+	return ix.search(ix.weigh(termFrequencies(doc)), doc.ID, k, minScore, exclude)
+}
+
+func (ix *Index) search(vec map[string]float64, self uint, k int, minScore float64, exclude func(uint) bool) []Match {
+	scores := make(map[uint]float64)
+	for term, w := range vec {
+		for _, p := range ix.postings[term] {
+			scores[p.id] += w * p.weight
+		}
+	}
+
+	matches := make([]Match, 0, len(scores))
+	for id, score := range scores {
+		if id == self || score < minScore || (exclude != nil && exclude(id)) {
+			continue
+		}
+		matches = append(matches, Match{ID: id, Score: score})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].ID > matches[j].ID // 同分時新文章（ID 較大）優先
+	})
+	if len(matches) > k {
+		matches = matches[:k]
+	}
+	return matches
+}
+
+// weigh 詞頻 → 次線性 TF × IDF，再做 L2 正規化。IDF 為 0 或未知的詞略過。
+func (ix *Index) weigh(tf map[string]float64) map[string]float64 {
+	vec := make(map[string]float64, len(tf))
+	var norm float64
+	for term, f := range tf {
+		idf := ix.idf[term]
+		if idf <= 0 {
+			continue
+		}
+		w := (1 + math.Log(f)) * idf
+		vec[term] = w
+		norm += w * w
+	}
+	if norm == 0 {
+		return vec
+	}
+	norm = math.Sqrt(norm)
+	for term := range vec {
+		vec[term] /= norm
+	}
+	return vec
+}
+
+// termFrequencies 各欄位斷詞後依權重累加詞頻（權重 < 1 的欄位也至少讓 log 項 ≥ 0）。
+func termFrequencies(d Document) map[string]float64 {
+	tf := make(map[string]float64)
+	for _, f := range d.Fields {
+		for _, tok := range textseg.Segment(f.Text) {
+			tf[tok] += f.Weight
+		}
+	}
+	for _, t := range d.Terms {
+		tf[t] += d.TermWeight
+	}
+	for term, f := range tf {
+		if f < 1 {
+			tf[term] = 1
+		}
+	}
+	return tf
+}
@@ -0,0 +1,66 @@
+// Package sitemap 輸出 sitemaps.org 0.9 格式的 urlset 與 sitemap index。
+//
+// 只負責格式轉換；要列哪些網址、何時切分由 services.SitemapService 決定。
+package sitemap
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// MaxURLs 單一 sitemap 檔案的網址上限（協定規定 50,000）。超過時需改用 sitemap index 切分。
+const MaxURLs = 50000
+
+const xmlns = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// URL sitemap 中的一筆網址；在 index 中則代表一個子 sitemap。
+type URL struct {
+	Loc     string
+	LastMod time.Time // 零值時不輸出 <lastmod>
+}
+
+type xmlURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type xmlURLSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	URLs    []xmlURL `xml:"url"`
+}
+
+type xmlIndex struct {
+	XMLName  xml.Name `xml:"sitemapindex"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Sitemaps []xmlURL `xml:"sitemap"`
+}
+
+// URLSet 輸出 <urlset>。
+func URLSet(urls []URL) ([]byte, error) {
+	return marshal(xmlURLSet{Xmlns: xmlns, URLs: toXML(urls)})
+}
+
+// Index 輸出 <sitemapindex>，sitemaps 為各子 sitemap 的網址與最後更新時間。
+func Index(sitemaps []URL) ([]byte, error) {
+	return marshal(xmlIndex{Xmlns: xmlns, Sitemaps: toXML(sitemaps)})
+}
+
+func toXML(urls []URL) []xmlURL {
+	out := make([]xmlURL, len(urls))
+	for i, u := range urls {
+		out[i] = xmlURL{Loc: u.Loc}
+		if !u.LastMod.IsZero() {
+			out[i].LastMod = u.LastMod.UTC().Format(time.RFC3339)
+		}
+	}
+	return out
+}
+
+func marshal(v any) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,96 @@
+// Package spam 公開寫入端點（留言、按讚、表情回應）的垃圾訊息檢查。
+//
+// Filter 依序詢問各個 Strategy，第一個判定為 spam 的即為結果；
+// 全部是本機規則（不呼叫外部服務），可透過 Config 開關與調整參數。
+package spam
+
+import "time"
+
+// 提交種類。
+const (
+	KindComment  = "comment"
+	KindLike     = "like"
+	KindReaction = "reaction"
+)
+
+// Submission 一次公開寫入請求的內容與來源。
+type Submission struct {
+	Kind        string
+	ArticleID   uint
+	IP          string
+	UserAgent   string
+	AuthorName  string
+	AuthorEmail string
+	Content     string // 按讚等一鍵操作為空
+	Honeypot    string // 前端隱藏欄位，真人不會填
+	FormToken   string // 載入表單時取得的 token（最短送出時間檢查）
+	ReceivedAt  time.Time
+}
+
+// Result 單一策略的判定。
+type Result struct {
+	Spam   bool
+	Reason string
+}
+
+// Strategy 垃圾訊息檢查策略。Check 不應修改 Submission。
+type Strategy interface {
+	Name() string
+	Check(s *Submission) Result
+}
+
+// Recorder 需要記住已寫入提交的策略（例如重複提交檢查）。
+// Filter.Check 不會記錄；呼叫端在提交成功寫入後呼叫 Filter.Record。
+type Recorder interface {
+	Record(s *Submission)
+}
+
+// Decision Filter 的最終判定；Spam=false 時 Strategy / Reason 為空。
+type Decision struct {
+	Spam     bool
+	Strategy string
+	Reason   string
+}
+
+// Filter 依序套用多個策略。
+type Filter struct {
+	strategies []Strategy
+}
+
+func NewFilter(strategies ...Strategy) *Filter {
+	return &Filter{strategies: strategies}
+}
+
+// Check 依序執行策略，遇到第一個判定 spam 即停止。
+func (f *Filter) Check(s *Submission) Decision {
+	if s.ReceivedAt.IsZero() {
+		s.ReceivedAt = time.Now()
+	}
+	for _, st := range f.strategies {
+		if r := st.Check(s); r.Spam {
+			return Decision{Spam: true, Strategy: st.Name(), Reason: r.Reason}
+		}
+	}
+	return Decision{}
+}
+
+// Record 通知各 Recorder 策略：此提交已成功寫入。
+func (f *Filter) Record(s *Submission) {
+	if s.ReceivedAt.IsZero() {
+		s.ReceivedAt = time.Now()
+	}
+	for _, st := range f.strategies {
+		if r, ok := st.(Recorder); ok {
+			r.Record(s)
+		}
+	}
+}
+
+// Strategies 啟用中的策略名稱（依執行順序）。
+func (f *Filter) Strategies() []string {
+	names := make([]string, len(f.strategies))
+	for i, st := range f.strategies {
+		names[i] = st.Name()
+	}
+	return names
+}
@@ -0,0 +1,174 @@
+package spam
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 策略名稱（亦為 Config SPAM_STRATEGIES 的設定值）。
+const (
+	StrategyHoneypot  = "honeypot"
+	StrategyToken     = "token"
+	StrategyLinks     = "links"
+	StrategyBlocklist = "blocklist"
+	StrategyDuplicate = "duplicate"
+)
+
+// ── honeypot ─────────────────────────────────────────────────────────────
+
+// Honeypot 隱藏欄位有值即判定為機器人。
+type Honeypot struct{}
+
+func (Honeypot) Name() string { return StrategyHoneypot }
+
+func (Honeypot) Check(s *Submission) Result {
+	if strings.TrimSpace(s.Honeypot) != "" {
+		return Result{Spam: true, Reason: "honeypot 欄位有值"}
+	}
+	return Result{}
+}
+
+// ── 最短送出時間 ──────────────────────────────────────────────────────────
+
+// MinSubmitTime 要求附上表單 token，且從取得 token 到送出至少經過 Min。
+// 一鍵操作（無 Content，例如按讚）沒有表單可放 token，不檢查。
+type MinSubmitTime struct {
+	Tokens *Tokens
+	Min    time.Duration
+}
+
+func (MinSubmitTime) Name() string { return StrategyToken }
+
+func (m MinSubmitTime) Check(s *Submission) Result {
+	if s.Content == "" {
+		return Result{}
+	}
+	if s.FormToken == "" {
+		return Result{Spam: true, Reason: "缺少表單 token"}
+	}
+	issued, err := m.Tokens.Verify(s.FormToken, s.ReceivedAt)
+	if err != nil {
+		return Result{Spam: true, Reason: "表單 token 無效或已過期"}
+	}
+	if elapsed := s.ReceivedAt.Sub(issued); elapsed < m.Min {
+		return Result{Spam: true, Reason: fmt.Sprintf("送出過快（%s）", elapsed.Round(time.Second))}
+	}
+	return Result{}
+}
+
+// ── 連結數 ────────────────────────────────────────────────────────────────
+
+var linkRe = regexp.MustCompile(`(?i)(https?://|www\.)`)
+
+// LinkLimit 內容中的連結數超過 Max 即判定為 spam。
+type LinkLimit struct {
+	Max int
+}
+
+func (LinkLimit) Name() string { return StrategyLinks }
+
+func (l LinkLimit) Check(s *Submission) Result {
+	if n := len(linkRe.FindAllStringIndex(s.Content, -1)); n > l.Max {
+		return Result{Spam: true, Reason: fmt.Sprintf("連結數 %d 超過上限 %d", n, l.Max)}
+	}
+	return Result{}
+}
+
+// ── 封鎖字詞 ──────────────────────────────────────────────────────────────
+
+// Blocklist 名稱、email 或內容含封鎖字詞（不分大小寫）即判定為 spam。
+type Blocklist struct {
+	words []string
+}
+
+func NewBlocklist(words []string) Blocklist {
+	var b Blocklist
+	for _, w := range words {
+		if w = strings.ToLower(strings.TrimSpace(w)); w != "" {
+			b.words = append(b.words, w)
+		}
+	}
+	return b
+}
+
+func (Blocklist) Name() string { return StrategyBlocklist }
+
+// Len 有效的封鎖字詞數。
+func (b Blocklist) Len() int { return len(b.words) }
+
+func (b Blocklist) Check(s *Submission) Result {
+	text := strings.ToLower(s.AuthorName + "\n" + s.AuthorEmail + "\n" + s.Content)
+	for _, w := range b.words {
+		if strings.Contains(text, w) {
+			return Result{Spam: true, Reason: fmt.Sprintf("含封鎖字詞 %q", w)}
+		}
+	}
+	return Result{}
+}
+
+// ── 重複提交 ──────────────────────────────────────────────────────────────
+
+// Duplicate 同一來源（IP）在 Window 內對同一篇文章提交相同內容即判定為 spam。
+//
+// 以「種類 + 文章 + IP + 正規化內容」判斷：不同讀者留下相同的短句（「謝謝分享」）不互相影響。
+// Check 只查詢；提交成功寫入後才由 Record 記下，被驗證擋下（400）後修正重送不算重複。
+// 無內容的一鍵操作（按讚）已由 article_likes 去重，不在此檢查。紀錄只存在記憶體，重啟後清空。
+type Duplicate struct {
+	window time.Duration
+
+	mu        sync.Mutex
+	seen      map[string]time.Time
+	lastSweep time.Time
+}
+
+func NewDuplicate(window time.Duration) *Duplicate {
+	return &Duplicate{window: window, seen: map[string]time.Time{}}
+}
+
+func (*Duplicate) Name() string { return StrategyDuplicate }
+
+func (d *Duplicate) Check(s *Submission) Result {
+	if s.Content == "" {
+		return Result{}
+	}
+	key := d.fingerprint(s)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if t, ok := d.seen[key]; ok && s.ReceivedAt.Sub(t) <= d.window {
+		return Result{Spam: true, Reason: fmt.Sprintf("%s 內重複提交", d.window)}
+	}
+	return Result{}
+}
+
+// Record 記下已成功寫入的提交，供之後的 Check 比對。
+func (d *Duplicate) Record(s *Submission) {
+	if s.Content == "" {
+		return
+	}
+	key := d.fingerprint(s)
+	now := s.ReceivedAt
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if now.Sub(d.lastSweep) > d.window {
+		for k, t := range d.seen {
+			if now.Sub(t) > d.window {
+				delete(d.seen, k)
+			}
+		}
+		d.lastSweep = now
+	}
+	d.seen[key] = now
+}
+
+func (d *Duplicate) fingerprint(s *Submission) string {
+	content := strings.ToLower(strings.Join(strings.Fields(s.Content), " "))
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d|%s|%s", s.Kind, s.ArticleID, s.IP, content)))
+	return hex.EncodeToString(sum[:])
+}
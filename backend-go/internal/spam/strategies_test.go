@@ -0,0 +1,32 @@
+package spam
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDuplicate(t *testing.T) {
+	now := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+	sub := func(ip, content string, at time.Duration) *Submission {
+		return &Submission{Kind: KindComment, ArticleID: 1, IP: ip, Content: content, ReceivedAt: now.Add(at)}
+	}
+
+	d := NewDuplicate(time.Hour)
+	if r := d.Check(sub("1.1.1.1", "謝謝分享", 0)); r.Spam {
+		t.Fatal("first submission flagged")
+	}
+	if r := d.Check(sub("1.1.1.1", "謝謝分享", time.Minute)); r.Spam {
+		t.Error("retry of unrecorded submission flagged")
+	}
+
+	d.Record(sub("1.1.1.1", "謝謝分享", time.Minute))
+	if r := d.Check(sub("1.1.1.1", "  謝謝分享 ", 2*time.Minute)); !r.Spam {
+		t.Error("repeat from same IP not flagged")
+	}
+	if r := d.Check(sub("2.2.2.2", "謝謝分享", 2*time.Minute)); r.Spam {
+		t.Error("same content from another reader flagged")
+	}
+	if r := d.Check(sub("1.1.1.1", "謝謝分享", 2*time.Hour)); r.Spam {
+		t.Error("repeat after window flagged")
+	}
+}
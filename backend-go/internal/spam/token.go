@@ -0,0 +1,55 @@
+package spam
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TokenMaxAge 表單 token 有效期限；超過視同沒有 token。
+const TokenMaxAge = 24 * time.Hour
+
+var errInvalidToken = errors.New("invalid form token")
+
+// Tokens 簽發與驗證「表單載入時間」token：{unix 秒}.{HMAC-SHA256}。
+// 不需儲存狀態；只證明 client 在某個時間點向伺服器取過 token。
+type Tokens struct {
+	secret []byte
+}
+
+func NewTokens(secret string) *Tokens {
+	return &Tokens{secret: []byte(secret)}
+}
+
+// Issue 簽發記錄 now 的 token。
+func (t *Tokens) Issue(now time.Time) string {
+	ts := strconv.FormatInt(now.Unix(), 10)
+	return ts + "." + t.sign(ts)
+}
+
+// Verify 驗證簽章並回傳 token 簽發時間。
+func (t *Tokens) Verify(token string, now time.Time) (time.Time, error) {
+	ts, sig, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(t.sign(ts))) {
+		return time.Time{}, errInvalidToken
+	}
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return time.Time{}, errInvalidToken
+	}
+	issued := time.Unix(sec, 0)
+	if issued.After(now.Add(time.Minute)) || now.Sub(issued) > TokenMaxAge {
+		return time.Time{}, errInvalidToken
+	}
+	return issued, nil
+}
+
+func (t *Tokens) sign(ts string) string {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte("form-token:" + ts))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,115 @@
+// Package textseg 中英混合文字的斷詞（全文檢索用）。
+//
+// PostgreSQL 的 text search parser 不會切中文：整段連續漢字會被當成一個詞，
+// 搜「串連」找不到「知識串連」。這裡在 Go 端先斷詞再交給 to_tsvector / to_tsquery：
+//
+//   - 英數字：連續字元為一詞，轉小寫
+//   - 中日韓文字：切成重疊的二字組（bigram），「知識串連」→ 知識 識串 串連；
+//     索引時另保留片段的最後一字（→ 連），讓單字前綴查詢也能命中結尾字；
+//     只有單一字時保留單字
+//   - 其餘（空白、標點、符號）：分隔符，不產生 token
+//
+// 索引與查詢使用同一套規則，中文詞組可做部分比對，且 bigram 的位置資訊
+// 讓 ts_rank_cd 對相鄰命中（原詞組完整出現）給較高分數。
+package textseg
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Term 查詢詞切出的一個比對單位。
+type Term struct {
+	Text   string
+	Prefix bool // 前綴比對（tsquery 的 :*）
+}
+
+// Segment 把文字切成索引用 token（依出現順序，可能重複）。
+func Segment(text string) []string {
+	var tokens []string
+	scan(text, func(run []rune, cjk bool) {
+		if !cjk {
+			tokens = append(tokens, string(run))
+			return
+		}
+		tokens = append(tokens, bigrams(run)...)
+		// 結尾字不是任何 bigram 的開頭，單字查詢（「學」:*）靠這個 unigram 命中「數學」
+		if len(run) > 1 {
+			tokens = append(tokens, string(run[len(run)-1]))
+		}
+	})
+	return tokens
+}
+
+// Join 斷詞後以空白串接，供 to_tsvector('simple', ...) 使用。
+func Join(text string) string {
+	return strings.Join(Segment(text), " ")
+}
+
+// QueryTerms 把單一查詢詞切成比對單位。
+//
+// 英數字詞做前綴比對（"kube" 可命中 "kubernetes"）；中文以 bigram 精確比對；
+// 單一中文字無法組成 bigram，改以前綴比對命中以該字開頭的 bigram 或片段結尾的單字。
+func QueryTerms(keyword string) []Term {
+	var terms []Term
+	scan(keyword, func(run []rune, cjk bool) {
+		if !cjk || len(run) == 1 {
+			terms = append(terms, Term{Text: string(run), Prefix: true})
+			return
+		}
+		for _, bg := range bigrams(run) {
+			terms = append(terms, Term{Text: bg})
+		}
+	})
+	return terms
+}
+
+// IsCJK 判斷是否為中日韓表意文字或假名、諺文。
+func IsCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		unicode.Is(unicode.Hangul, r)
+}
+
+// scan 依字元類別切出連續片段：英數字片段（已轉小寫）或中日韓文字片段。
+func scan(text string, emit func(run []rune, cjk bool)) {
+	var run []rune
+	runCJK := false
+	flush := func() {
+		if len(run) > 0 {
+			emit(run, runCJK)
+			run = nil
+		}
+	}
+	for _, r := range text {
+		switch {
+		case IsCJK(r):
+			if !runCJK {
+				flush()
+			}
+			runCJK = true
+			run = append(run, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if runCJK {
+				flush()
+			}
+			runCJK = false
+			run = append(run, unicode.ToLower(r))
+		default:
+			flush()
+		}
+	}
+	flush()
+}
+
+func bigrams(run []rune) []string {
+	if len(run) == 1 {
+		return []string{string(run)}
+	}
+	out := make([]string, 0, len(run)-1)
+	for i := 0; i+1 < len(run); i++ {
+		out = append(out, string(run[i:i+2]))
+	}
+	return out
+}
@@ -0,0 +1,121 @@
+package textseg
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSegment(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"純英文轉小寫", "Hello Go World", []string{"hello", "go", "world"}},
+		{"中文 bigram", "知識串連", []string{"知識", "識串", "串連", "連"}},
+		{"單一中文字", "我", []string{"我"}},
+		{"中英混合無空白", "用Go寫API", []string{"用", "go", "寫", "api"}},
+		{"中英混合含數字", "Kubernetes部署v2版本", []string{"kubernetes", "部署", "署", "v2", "版本", "本"}},
+		{"標點為分隔", "知識，串連！node.js", []string{"知識", "識", "串連", "連", "node", "js"}},
+		{"全形空白與換行", "文章　回顧\n檢索", []string{"文章", "章", "回顧", "顧", "檢索", "索"}},
+		{"日文假名", "テスト", []string{"テス", "スト", "ト"}},
+		{"空字串", "", nil},
+		{"只有符號", "!@#$%", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Segment(c.in); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Segment(%q) = %q, want %q", c.in, got, c.want)
+			}
+		})
+	}
+}
+
+func TestJoin(t *testing.T) {
+	got := Join("PaulFun 部落格：知識串連")
+	want := "paulfun 部落 落格 格 知識 識串 串連 連"
+	if got != want {
+		t.Errorf("Join = %q, want %q", got, want)
+	}
+}
+
+func TestQueryTerms(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want []Term
+	}{
+		{"英文前綴", "Kube", []Term{{"kube", true}}},
+		{"中文 bigram 精確比對", "串連", []Term{{"串連", false}}},
+		{"長中文詞", "知識串連", []Term{{"知識", false}, {"識串", false}, {"串連", false}}},
+		{"單一中文字前綴", "串", []Term{{"串", true}}},
+		{"中英混合", "Go語言", []Term{{"go", true}, {"語言", false}}},
+		{"tsquery 運算子被丟棄", "a&b|!c:*", []Term{{"a", true}, {"b", true}, {"c", true}}},
+		{"只有符號", "()&|", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := QueryTerms(c.in); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("QueryTerms(%q) = %+v, want %+v", c.in, got, c.want)
+			}
+		})
+	}
+}
+
+// 查詢詞的每個 bigram 都必須出現在包含該詞的文件 token 中（部分比對的前提）。
+func TestQueryTermsMatchSegmentedDocument(t *testing.T) {
+	doc := "這篇文章介紹 PaulFun Blogger 的知識串連功能，以及 Kubernetes 部署流程。"
+	tokens := map[string]bool{}
+	for _, tok := range Segment(doc) {
+		tokens[tok] = true
+	}
+
+	for _, q := range []string{"知識串連", "串連", "部署流程", "文章"} {
+		for _, term := range QueryTerms(q) {
+			if !term.Prefix && !tokens[term.Text] {
+				t.Errorf("query %q: term %q not found in document tokens", q, term.Text)
+			}
+		}
+	}
+
+	for _, term := range QueryTerms("向量搜尋") {
+		if tokens[term.Text] {
+			t.Errorf("unrelated term %q unexpectedly found in document tokens", term.Text)
+		}
+	}
+}
+
+// 單字查詢以前綴比對，片段開頭、中間與結尾的字都要能命中。
+func TestSingleCharQueryMatchesSegmentedDocument(t *testing.T) {
+	tokens := Segment("數學，統計")
+	for _, q := range []string{"數", "學", "統", "計"} {
+		terms := QueryTerms(q)
+		if len(terms) != 1 || !terms[0].Prefix {
+			t.Fatalf("QueryTerms(%q) = %+v, want single prefix term", q, terms)
+		}
+		found := false
+		for _, tok := range tokens {
+			if strings.HasPrefix(tok, terms[0].Text) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("query %q: no token in %q has prefix %q", q, tokens, terms[0].Text)
+		}
+	}
+}
+
+func TestIsCJK(t *testing.T) {
+	for _, r := range "中文かなカナ한글" {
+		if !IsCJK(r) {
+			t.Errorf("IsCJK(%q) = false, want true", r)
+		}
+	}
+	for _, r := range "aZ09，。 !" {
+		if IsCJK(r) {
+			t.Errorf("IsCJK(%q) = true, want false", r)
+		}
+	}
+}